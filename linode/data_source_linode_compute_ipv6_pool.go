@@ -13,35 +13,48 @@ func dataSourceLinodeComputeIPv6Pool() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"range": &schema.Schema{
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:        schema.TypeString,
+				Description: "The IPv6 range to look up.",
+				Required:    true,
+			},
+
+			"prefix": &schema.Schema{
+				Type:        schema.TypeInt,
+				Description: "The prefix length of the IPv6 range.",
+				Computed:    true,
 			},
 
 			"region": &schema.Schema{
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:        schema.TypeString,
+				Description: "The region where the IPv6 range is routed.",
+				Computed:    true,
+			},
+
+			"linodes": &schema.Schema{
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "The IDs of the Linode instances the IPv6 range is assigned to.",
+				Computed:    true,
 			},
 		},
 	}
 }
 
 func dataSourceLinodeComputeIPv6PoolRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*linodego.Client)
+	client := meta.(linodego.Client)
 
-	pools, err := client.ListIPv6Pools(nil)
+	reqPool := d.Get("range").(string)
+
+	pool, err := client.GetIPv6Pool(reqPool)
 	if err != nil {
-		return fmt.Errorf("Error listing pools: %s", err)
+		return fmt.Errorf("Error getting IPv6 pool %s: %s", reqPool, err)
 	}
 
-	reqPool := d.Get("").(string)
-
-	for _, pool := range pools {
-		if pool.Range == reqPool {
-			d.SetId(pool.Range)
-			d.Set("region", pool.Region)
-			return nil
-		}
-	}
+	d.SetId(pool.Range)
+	d.Set("range", pool.Range)
+	d.Set("prefix", pool.Prefix)
+	d.Set("region", pool.Region)
+	d.Set("linodes", pool.Linodes)
 
-	return fmt.Errorf("Pool not found")
+	return nil
 }
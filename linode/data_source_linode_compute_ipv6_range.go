@@ -2,6 +2,9 @@ package linode
 
 import (
 	"fmt"
+	"net"
+	"strconv"
+	"strings"
 
 	"github.com/chiefy/linodego"
 	"github.com/hashicorp/terraform/helper/schema"
@@ -13,35 +16,66 @@ func dataSourceLinodeComputeIPv6Range() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"range": &schema.Schema{
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:         schema.TypeString,
+				Description:  "The IPv6 range to look up, with or without its prefix length.",
+				Required:     true,
+				ValidateFunc: validateIPv6Range,
+			},
+
+			"prefix": &schema.Schema{
+				Type:        schema.TypeInt,
+				Description: "The prefix length of the IPv6 range.",
+				Computed:    true,
 			},
 
 			"region": &schema.Schema{
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:        schema.TypeString,
+				Description: "The region where the IPv6 range is routed.",
+				Computed:    true,
+			},
+
+			"linodes": &schema.Schema{
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "The IDs of the Linode instances the IPv6 range is assigned to.",
+				Computed:    true,
 			},
 		},
 	}
 }
 
 func dataSourceLinodeComputeIPv6RangeRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*linodego.Client)
+	client := meta.(linodego.Client)
+
+	// The API looks up ranges by address only, the configured range is kept as it is
+	reqRange := d.Get("range").(string)
+	address := strings.SplitN(reqRange, "/", 2)[0]
 
-	ranges, err := client.ListIPv6Ranges(nil)
+	r, err := client.GetIPv6Range(address)
 	if err != nil {
-		return fmt.Errorf("Error listing ranges: %s", err)
+		return fmt.Errorf("Error getting IPv6 range %s: %s", reqRange, err)
 	}
 
-	reqRange := d.Get("range").(string)
+	d.SetId(r.Range)
+	d.Set("prefix", r.Prefix)
+	d.Set("region", r.Region)
+	d.Set("linodes", r.Linodes)
+
+	return nil
+}
 
-	for _, r := range ranges {
-		if r.Range == reqRange {
-			d.SetId(r.Range)
-			d.Set("region", r.Region)
-			return nil
+// validateIPv6Range checks that a string is an IPv6 address, optionally followed by a /prefix length
+func validateIPv6Range(v interface{}, k string) (ws []string, es []error) {
+	value := v.(string)
+	parts := strings.SplitN(value, "/", 2)
+	if ip := net.ParseIP(parts[0]); ip == nil || ip.To4() != nil {
+		es = append(es, fmt.Errorf("%q must be an IPv6 range such as 2600:3c01::/64, got %s", k, value))
+		return
+	}
+	if len(parts) == 2 {
+		if prefix, err := strconv.Atoi(parts[1]); err != nil || prefix < 0 || prefix > 128 {
+			es = append(es, fmt.Errorf("%q must have a prefix length between 0 and 128, got %s", k, value))
 		}
 	}
-
-	return fmt.Errorf("Pool not found")
+	return
 }
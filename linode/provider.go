@@ -19,8 +19,25 @@ func Provider() terraform.ResourceProvider {
 			},
 		},
 
+		DataSourcesMap: map[string]*schema.Resource{
+			"linode_compute_ipv6_pool":  dataSourceLinodeComputeIPv6Pool(),
+			"linode_compute_ipv6_range": dataSourceLinodeComputeIPv6Range(),
+		},
+
 		ResourcesMap: map[string]*schema.Resource{
-			"linode_linode": resourceLinodeLinode(),
+			"linode_domain":              resourceLinodeDomain(),
+			"linode_domain_record":       resourceLinodeDomainRecord(),
+			"linode_domain_zone":         resourceLinodeDomainZone(),
+			"linode_instance_config":     resourceLinodeInstanceConfig(),
+			"linode_instance_disk":       resourceLinodeInstanceDisk(),
+			"linode_instance_snapshot":   resourceLinodeInstanceSnapshot(),
+			"linode_linode":              resourceLinodeLinode(),
+			"linode_nodebalancer":        resourceLinodeNodeBalancer(),
+			"linode_nodebalancer_config": resourceLinodeNodeBalancerConfig(),
+			"linode_nodebalancer_node":   resourceLinodeNodeBalancerNode(),
+			"linode_stackscript":         resourceLinodeStackscript(),
+			"linode_volume":              resourceLinodeVolume(),
+			"linode_volume_attachment":   resourceLinodeVolumeAttachment(),
 		},
 
 		ConfigureFunc: providerConfigure,
@@ -1,9 +1,12 @@
 package linode
 
 import (
+	"crypto/rand"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"math/big"
+	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
@@ -29,6 +32,13 @@ const (
 	InstanceRestoring    = "restoring"
 )
 
+// InstanceDisk.Status values
+const (
+	DiskReady    = "ready"
+	DiskNotReady = "not ready"
+	DiskDeleting = "deleting"
+)
+
 // WaitTimeout is the default number of seconds to wait for Linode instance status changes
 const WaitTimeout = 600
 
@@ -48,27 +58,29 @@ func init() {
 
 func resourceLinodeLinode() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceLinodeLinodeCreate,
-		Read:   resourceLinodeLinodeRead,
-		Update: resourceLinodeLinodeUpdate,
-		Delete: resourceLinodeLinodeDelete,
-		Exists: resourceLinodeLinodeExists,
+		Create:        resourceLinodeLinodeCreate,
+		Read:          resourceLinodeLinodeRead,
+		Update:        resourceLinodeLinodeUpdate,
+		Delete:        resourceLinodeLinodeDelete,
+		Exists:        resourceLinodeLinodeExists,
+		CustomizeDiff: resourceLinodeLinodeCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
 		Schema: map[string]*schema.Schema{
 			"image": &schema.Schema{
-				Type:         schema.TypeString,
-				Description:  "The image to deploy to the disk.",
-				Required:     true,
-				ForceNew:     true,
-				InputDefault: "linode/debian9",
+				Type:          schema.TypeString,
+				Description:   "The image to deploy to the disk.",
+				Optional:      true,
+				ConflictsWith: []string{"disk"},
+				InputDefault:  "linode/debian9",
 			},
 			"kernel": &schema.Schema{
-				Type:         schema.TypeString,
-				Description:  "The kernel used at boot by the Linode Config. (examples: linode/latest-64bit, linode/grub2, linode/direct-disk)",
-				Required:     true,
-				InputDefault: "linode/direct-disk",
+				Type:          schema.TypeString,
+				Description:   "The kernel used at boot by the Linode Config. (examples: linode/latest-64bit, linode/grub2, linode/direct-disk)",
+				Optional:      true,
+				ConflictsWith: []string{"config"},
+				InputDefault:  "linode/direct-disk",
 			},
 			"name": &schema.Schema{
 				Type:        schema.TypeString,
@@ -88,11 +100,16 @@ func resourceLinodeLinode() *schema.Resource {
 			},
 			"region": &schema.Schema{
 				Type:         schema.TypeString,
-				Description:  "The region where this instance will be deployed.",
+				Description:  "The region where this instance will be deployed. Changing it replaces the instance, unless migrate_on_region_change is set.",
 				Required:     true,
-				ForceNew:     true,
 				InputDefault: "us-east",
 			},
+			"migrate_on_region_change": &schema.Schema{
+				Type:        schema.TypeBool,
+				Description: "Migrate the instance with its disks to the new region when region changes, instead of replacing it. Its IP addresses change.",
+				Optional:    true,
+				Default:     false,
+			},
 			"size": &schema.Schema{
 				Type:     schema.TypeInt,
 				Optional: true, // @TODO seems a bug that Optional is required when Removed is set
@@ -105,10 +122,16 @@ func resourceLinodeLinode() *schema.Resource {
 				Default:     "g5-standard-1",
 			},
 			"status": &schema.Schema{
-				Type:        schema.TypeInt,
+				Type:        schema.TypeString,
 				Description: "The status of the instance, indicating the current readiness state.",
 				Computed:    true,
 			},
+			"booted": &schema.Schema{
+				Type:        schema.TypeBool,
+				Description: "Whether the instance should be running. An instance that is shut down or booted outside of Terraform is returned to this state.",
+				Optional:    true,
+				Default:     true,
+			},
 			"plan_storage": &schema.Schema{
 				Type: schema.TypeInt,
 				// Optional: true, // @TODO seems a bug that Optional is required when Removed is set
@@ -148,23 +171,26 @@ func resourceLinodeLinode() *schema.Resource {
 				Type:          schema.TypeList,
 				Elem:          &schema.Schema{Type: schema.TypeString},
 				Description:   "The public keys to be used for accessing the root account via ssh.",
-				Required:      true,
-				ForceNew:      true,
+				Optional:      true,
+				ConflictsWith: []string{"disk"},
 				StateFunc:     sshKeyState,
 				PromoteSingle: true,
 			},
 			"root_password": &schema.Schema{
-				Type:        schema.TypeString,
-				Description: "The password that will be initialially assigned to the 'root' user account.",
-				Required:    true,
-				ForceNew:    true,
-				StateFunc:   rootPasswordState,
+				Type:          schema.TypeString,
+				Description:   "The password of the 'root' user account. Changing it resets the password of the root disk in place. A random password is generated and stored in plain text when it is not given.",
+				Optional:      true,
+				Computed:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"disk"},
+				StateFunc:     rootPasswordState,
 			},
 			"helper_distro": &schema.Schema{
-				Type:        schema.TypeBool,
-				Description: "Controls the behavior of the Linode Config's Distribution Helper setting.",
-				Optional:    true,
-				Default:     true,
+				Type:          schema.TypeBool,
+				Description:   "Controls the behavior of the Linode Config's Distribution Helper setting.",
+				Optional:      true,
+				Default:       true,
+				ConflictsWith: []string{"config"},
 			},
 			"manage_private_ip_automatically": &schema.Schema{
 				Type:     schema.TypeBool,
@@ -172,22 +198,309 @@ func resourceLinodeLinode() *schema.Resource {
 				Removed:  "See 'helper_network'",
 			},
 			"helper_network": &schema.Schema{
-				Type:        schema.TypeBool,
-				Description: "Controls the behavior of the Linode Config's Network Helper setting, used to automatically configure additional IP addresses assigned to this instance.",
-				Optional:    true,
-				Default:     true,
+				Type:          schema.TypeBool,
+				Description:   "Controls the behavior of the Linode Config's Network Helper setting, used to automatically configure additional IP addresses assigned to this instance.",
+				Optional:      true,
+				Default:       true,
+				ConflictsWith: []string{"config"},
 			},
 			"disk_expansion": &schema.Schema{
 				Type:        schema.TypeBool,
-				Description: "Controls the Linode Terraform provider's behavior of resizing the disk to full size after resizing to a larger Linode type.",
+				Description: "Controls the Linode Terraform provider's behavior of expanding the largest disk to full size after resizing to a larger Linode type.",
 				Optional:    true,
 				Default:     false,
 			},
 			"swap_size": &schema.Schema{
-				Type:        schema.TypeInt,
-				Description: "Storage (MB) to dedicate to local swap disk (memory) space.",
+				Type:             schema.TypeInt,
+				Description:      "Storage (MB) to dedicate to local swap disk (memory) space.",
+				Optional:         true,
+				Default:          512,
+				ConflictsWith:    []string{"disk"},
+				DiffSuppressFunc: suppressLinodeImageLayoutDiff,
+			},
+			"disk": &schema.Schema{
+				Type:        schema.TypeList,
+				Description: "The disks of the Linode instance. Declaring disks replaces the root and swap disks deployed from image.",
+				Optional:    true,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:        schema.TypeInt,
+							Description: "The ID of the disk.",
+							Computed:    true,
+						},
+						"label": &schema.Schema{
+							Type:        schema.TypeString,
+							Description: "The label of the disk, unique among the disks of the Linode instance.",
+							Required:    true,
+						},
+						"size": &schema.Schema{
+							Type:        schema.TypeInt,
+							Description: "The size of the disk in MB.",
+							Required:    true,
+						},
+						"filesystem": &schema.Schema{
+							Type:         schema.TypeString,
+							Description:  "The filesystem of the disk (ext3, ext4, swap, raw or initrd).",
+							Optional:     true,
+							Default:      "ext4",
+							ValidateFunc: validateOneOf("ext3", "ext4", "swap", "raw", "initrd"),
+						},
+						"image": &schema.Schema{
+							Type:        schema.TypeString,
+							Description: "The image to deploy to the disk.",
+							Optional:    true,
+						},
+						// Unlike the root_password of the instance this is not hashed, since it is needed again
+						// whenever the disk is deployed again
+						"root_password": &schema.Schema{
+							Type:        schema.TypeString,
+							Description: "The password assigned to the 'root' user account of the image deployed to the disk.",
+							Optional:    true,
+							Sensitive:   true,
+						},
+						"ssh_key": &schema.Schema{
+							Type:        schema.TypeList,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The public keys authorized for the 'root' user account of the image deployed to the disk.",
+							Optional:    true,
+						},
+						"allow_redeploy": &schema.Schema{
+							Type:        schema.TypeBool,
+							Description: "Allows changing the filesystem, image or ssh_key of the disk, which deletes the disk and creates it again, losing its data.",
+							Optional:    true,
+							Default:     false,
+						},
+					},
+				},
+			},
+			"config": &schema.Schema{
+				Type:        schema.TypeList,
+				Description: "The configuration profiles of the Linode instance. Declaring configs replaces the config created from kernel and the helper settings.",
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: linodeConfigSchema(map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:        schema.TypeInt,
+							Description: "The ID of the config.",
+							Computed:    true,
+						},
+					}),
+				},
+			},
+			"boot_config_label": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "The label of the config to boot the Linode instance with.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"rescue": &schema.Schema{
+				Type:        schema.TypeList,
+				Description: "While declared, the instance is booted into Rescue Mode with these devices attached. Removing it reboots the instance with its config.",
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"devices": &schema.Schema{
+							Type:        schema.TypeList,
+							Description: "The disks and Volumes attached in Rescue Mode, as /dev/sda to /dev/sdg.",
+							Required:    true,
+							MaxItems:    1,
+							Elem:        &schema.Resource{Schema: linodeConfigDevicesSchema()},
+						},
+					},
+				},
+			},
+			"rebuild_in_place": &schema.Schema{
+				Type:          schema.TypeBool,
+				Description:   "Controls whether changes to image and ssh_key rebuild the Linode instance in place, keeping its ID and IP addresses, instead of replacing it.",
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"disk"},
+			},
+			"stackscript_id": &schema.Schema{
+				Type:          schema.TypeInt,
+				Description:   "The StackScript to deploy to the root disk. The StackScript must be compatible with the chosen image.",
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"disk"},
+			},
+			"stackscript_data": &schema.Schema{
+				Type:          schema.TypeMap,
+				Description:   "The values of the StackScript's user-defined fields. Fields that are not set are planned with their default values.",
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"disk"},
+			},
+			"alerts": &schema.Schema{
+				Type:        schema.TypeList,
+				Description: "The thresholds of the alerts of the instance. Thresholds that are not given keep their current values, and 0 disables an alert.",
+				Optional:    true,
+				Computed:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cpu": &schema.Schema{
+							Type:        schema.TypeInt,
+							Description: "The percentage of CPU usage, averaged over two hours, that triggers an alert.",
+							Optional:    true,
+							Computed:    true,
+						},
+						"io": &schema.Schema{
+							Type:        schema.TypeInt,
+							Description: "The disk IO operations per second, averaged over two hours, that trigger an alert.",
+							Optional:    true,
+							Computed:    true,
+						},
+						"network_in": &schema.Schema{
+							Type:        schema.TypeInt,
+							Description: "The incoming traffic in Mbit/s, averaged over two hours, that triggers an alert.",
+							Optional:    true,
+							Computed:    true,
+						},
+						"network_out": &schema.Schema{
+							Type:        schema.TypeInt,
+							Description: "The outgoing traffic in Mbit/s, averaged over two hours, that triggers an alert.",
+							Optional:    true,
+							Computed:    true,
+						},
+						"transfer_quota": &schema.Schema{
+							Type:        schema.TypeInt,
+							Description: "The percentage of the network transfer quota used that triggers an alert.",
+							Optional:    true,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"backup_id": &schema.Schema{
+				Type:          schema.TypeInt,
+				Description:   "The ID of a backup to restore to the new instance, in place of deploying image. The disks and configs of the backup are restored.",
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"image", "disk", "config", "stackscript_id", "source_linode_id"},
+			},
+			"source_linode_id": &schema.Schema{
+				Type:          schema.TypeInt,
+				Description:   "The ID of a Linode instance to clone the disks and configs of to the new instance, in place of deploying image.",
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"image", "disk", "config", "stackscript_id", "backup_id"},
+			},
+			"source_disks": &schema.Schema{
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The labels of the disks of source_linode_id to clone. All disks are cloned when not given.",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"source_configs": &schema.Schema{
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The labels of the configs of source_linode_id to clone, with the disks they use. All configs are cloned when neither source_disks nor source_configs are given.",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"backups_enabled": &schema.Schema{
+				Type:        schema.TypeBool,
+				Description: "Whether the Backup service is enabled for the instance. Disabling it deletes all of its backups.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"backups_schedule": &schema.Schema{
+				Type:        schema.TypeList,
+				Description: "When the automatic backups of the instance are taken.",
 				Optional:    true,
-				Default:     512,
+				Computed:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"day": &schema.Schema{
+							Type:         schema.TypeString,
+							Description:  "The day of the week the weekly backup is taken on. (Scheduling lets the Backup service choose)",
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validateOneOf("Scheduling", "Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"),
+						},
+						"window": &schema.Schema{
+							Type:         schema.TypeString,
+							Description:  "The two-hour window, in UTC, the backups are taken in, from W0 (00:00-02:00) to W22 (22:00-24:00). (Scheduling lets the Backup service choose)",
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validateOneOf(linodeBackupWindows...),
+						},
+					},
+				},
+			},
+			"backups": &schema.Schema{
+				Type:        schema.TypeList,
+				Description: "The latest automatic backups and manual snapshot of the instance.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"label": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": &schema.Schema{
+							Type:        schema.TypeString,
+							Description: "Whether the backup was taken automatically or is a manual snapshot. (auto, snapshot)",
+							Computed:    true,
+						},
+						"status": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"created": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"finished": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"configs": &schema.Schema{
+							Type:     schema.TypeList,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Computed: true,
+						},
+						"disks": &schema.Schema{
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     linodeSnapshotDiskSchema(),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// linodeBackupWindows are the two-hour windows automatic backups can be scheduled in
+var linodeBackupWindows = []string{"Scheduling", "W0", "W2", "W4", "W6", "W8", "W10", "W12", "W14", "W16", "W18", "W20", "W22"}
+
+// linodeSnapshotDiskSchema is the schema of the disks saved in a backup
+func linodeSnapshotDiskSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"label": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"size": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"filesystem": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
 			},
 		},
 	}
@@ -220,37 +533,31 @@ func resourceLinodeLinodeRead(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("Failed to find the specified Linode instance because %s", err)
 	}
 
-	instanceNetwork, err := client.GetInstanceIPAddresses(int(id))
-
-	if err != nil {
-		return fmt.Errorf("Failed to get the IPs for Linode instance %s because %s", d.Id(), err)
-	}
-
-	public, private := instanceNetwork.IPv4.Public, instanceNetwork.IPv4.Private
-
-	if len(public) > 0 {
-		d.Set("ip_address", public[0].Address)
-
-		d.SetConnInfo(map[string]string{
-			"type": "ssh",
-			"host": public[0].Address,
-		})
-	}
-
-	if len(private) > 0 {
-		d.Set("private_networking", true)
-		d.Set("private_ip_address", private[0].Address)
-	} else {
-		d.Set("private_networking", false)
+	if err = readLinodeIPAddresses(&client, instance.ID, d); err != nil {
+		return err
 	}
 
 	d.Set("name", instance.Label)
 	d.Set("status", instance.Status)
+	d.Set("booted", isLinodeBooted(instance))
 	d.Set("type", instance.Type)
 	d.Set("region", instance.Region)
 
 	d.Set("group", instance.Group)
 
+	if err = readLinodeBackups(&client, instance, d); err != nil {
+		return err
+	}
+	if instance.Alerts != nil {
+		d.Set("alerts", []interface{}{map[string]interface{}{
+			"cpu":            instance.Alerts.CPU,
+			"io":             instance.Alerts.IO,
+			"network_in":     instance.Alerts.NetworkIn,
+			"network_out":    instance.Alerts.NetworkOut,
+			"transfer_quota": instance.Alerts.TransferQuota,
+		}})
+	}
+
 	planStorage := instance.Specs.Disk
 	d.Set("plan_storage", planStorage)
 	d.Set("storage", planStorage)
@@ -262,19 +569,22 @@ func resourceLinodeLinodeRead(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	planStorageUtilized := 0
-	swapSize := 0
-
 	for _, disk := range instanceDisks {
 		planStorageUtilized += disk.Size
-		// Determine if swap exists and the size.  If it does not exist, swap_size=0
-		if disk.Filesystem == "swap" {
-			swapSize = disk.Size
-			d.Set("swap_size", swapSize)
-		}
+	}
+
+	// Determine if swap exists and the size.  If it does not exist, swap_size=0
+	if _, swapDisk := findLinodeRootAndSwapDisks(instanceDisks); swapDisk != nil {
+		d.Set("swap_size", swapDisk.Size)
+	} else {
+		d.Set("swap_size", 0)
 	}
 
 	d.Set("plan_storage_utilized", planStorageUtilized)
 	d.Set("storage_utilized", planStorageUtilized)
+	// Disks declared with disk blocks are managed, otherwise all disks are shown
+	declaredDisks := d.Get("disk").([]interface{})
+	d.Set("disk", flattenLinodeDisks(instanceDisks, declaredDisks, len(declaredDisks) == 0 || d.Get("image").(string) != ""))
 
 	//diskExpansion := d.Get("disk_expansion").(bool)
 	//d.Set("disk_expansion", diskExpansion)
@@ -282,10 +592,21 @@ func resourceLinodeLinodeRead(d *schema.ResourceData, meta interface{}) error {
 	configs, err := client.ListInstanceConfigs(int(id), nil)
 	if err != nil {
 		return fmt.Errorf("Failed to get the config for Linode instance %d (%s) because %s", instance.ID, instance.Label, err)
-	} else if len(configs) != 1 {
+	}
+
+	if declared := d.Get("config").([]interface{}); len(declared) > 0 {
+		d.Set("config", flattenLinodeConfigs(configs, declared, instanceDisks))
+		if findLinodeConfig(configs, d.Get("boot_config_label").(string)) == nil {
+			d.Set("boot_config_label", "")
+		}
+		return nil
+	}
+
+	config := findLinodeConfig(configs, d.Get("boot_config_label").(string), fmt.Sprintf("linode%d-config", instance.ID))
+	if config == nil {
 		return nil
 	}
-	config := configs[0]
+	d.Set("boot_config_label", config.Label)
 
 	/**
 	// This doesn't really tell us much.  This will flunk if an ImageName is used to deploy, since getImage will return
@@ -306,6 +627,161 @@ func resourceLinodeLinodeRead(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
+// readLinodeBackups sets the Backup service settings of a Linode instance and its latest backups
+func readLinodeBackups(client *linodego.Client, instance *linodego.Instance, d *schema.ResourceData) error {
+	if instance.Backups == nil || !instance.Backups.Enabled {
+		d.Set("backups_enabled", false)
+		d.Set("backups_schedule", []interface{}{})
+		d.Set("backups", []interface{}{})
+		return nil
+	}
+
+	d.Set("backups_enabled", true)
+	d.Set("backups_schedule", []interface{}{map[string]interface{}{
+		"day":    instance.Backups.Schedule.Day,
+		"window": instance.Backups.Schedule.Window,
+	}})
+
+	backups, err := client.GetInstanceBackups(instance.ID)
+	if err != nil {
+		return fmt.Errorf("Failed to get the backups of Linode instance %d because %s", instance.ID, err)
+	}
+	snapshots := append([]*linodego.InstanceSnapshot{}, backups.Automatic...)
+	if backups.Snapshot != nil {
+		for _, snapshot := range []*linodego.InstanceSnapshot{backups.Snapshot.Current, backups.Snapshot.InProgress} {
+			if snapshot != nil {
+				snapshots = append(snapshots, snapshot)
+			}
+		}
+	}
+	result := make([]interface{}, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		result = append(result, flattenLinodeSnapshot(snapshot))
+	}
+	d.Set("backups", result)
+
+	return nil
+}
+
+// flattenLinodeSnapshot returns the attributes of a backup of a Linode instance
+func flattenLinodeSnapshot(snapshot *linodego.InstanceSnapshot) map[string]interface{} {
+	disks := make([]interface{}, 0, len(snapshot.Disks))
+	for _, disk := range snapshot.Disks {
+		disks = append(disks, map[string]interface{}{
+			"label":      disk.Label,
+			"size":       disk.Size,
+			"filesystem": disk.Filesystem,
+		})
+	}
+	result := map[string]interface{}{
+		"id":       snapshot.ID,
+		"label":    snapshot.Label,
+		"type":     snapshot.Type,
+		"status":   snapshot.Status,
+		"created":  "",
+		"finished": "",
+		"configs":  snapshot.Configs,
+		"disks":    disks,
+	}
+	if snapshot.Created != nil {
+		result["created"] = snapshot.Created.Format(time.RFC3339)
+	}
+	if snapshot.Finished != nil {
+		result["finished"] = snapshot.Finished.Format(time.RFC3339)
+	}
+	return result
+}
+
+// updateLinodeBackups enables or cancels the Backup service of a Linode instance and updates its schedule
+func updateLinodeBackups(client *linodego.Client, linodeID int, d *schema.ResourceData) error {
+	enabled := d.Get("backups_enabled").(bool)
+	if d.HasChange("backups_enabled") {
+		var err error
+		if enabled {
+			_, err = client.EnableInstanceBackups(linodeID)
+		} else {
+			_, err = client.CancelInstanceBackups(linodeID)
+		}
+		if err != nil {
+			return fmt.Errorf("Failed to update the Backup service of Linode instance %d because %s", linodeID, err)
+		}
+		d.SetPartial("backups_enabled")
+	}
+
+	schedules := d.Get("backups_schedule").([]interface{})
+	if enabled && len(schedules) > 0 && d.HasChange("backups_schedule") {
+		schedule := schedules[0].(map[string]interface{})
+		backups := &linodego.InstanceBackup{}
+		backups.Schedule.Day = schedule["day"].(string)
+		backups.Schedule.Window = schedule["window"].(string)
+		if _, err := client.UpdateInstance(linodeID, &linodego.InstanceUpdateOptions{Backups: backups}); err != nil {
+			return fmt.Errorf("Failed to update the backup schedule of Linode instance %d because %s", linodeID, err)
+		}
+		d.SetPartial("backups_schedule")
+	}
+
+	return nil
+}
+
+// updateLinodeAlerts sets the alert thresholds given in the alerts block of a Linode instance, keeping the current
+// values of the others
+func updateLinodeAlerts(client *linodego.Client, instance *linodego.Instance, d *schema.ResourceData) error {
+	if !d.HasChange("alerts") {
+		return nil
+	}
+
+	alerts := linodego.InstanceAlert{}
+	if instance.Alerts != nil {
+		alerts = *instance.Alerts
+	}
+	for key, threshold := range map[string]*int{
+		"cpu":            &alerts.CPU,
+		"io":             &alerts.IO,
+		"network_in":     &alerts.NetworkIn,
+		"network_out":    &alerts.NetworkOut,
+		"transfer_quota": &alerts.TransferQuota,
+	} {
+		if value, ok := d.GetOkExists("alerts.0." + key); ok {
+			*threshold = value.(int)
+		}
+	}
+
+	if _, err := client.UpdateInstance(instance.ID, &linodego.InstanceUpdateOptions{Alerts: &alerts}); err != nil {
+		return fmt.Errorf("Failed to update the alerts of Linode instance %d because %s", instance.ID, err)
+	}
+	d.SetPartial("alerts")
+	return nil
+}
+
+// readLinodeIPAddresses sets the public and private IPv4 addresses of a Linode instance
+func readLinodeIPAddresses(client *linodego.Client, linodeID int, d *schema.ResourceData) error {
+	instanceNetwork, err := client.GetInstanceIPAddresses(linodeID)
+
+	if err != nil {
+		return fmt.Errorf("Failed to get the IPs for Linode instance %d because %s", linodeID, err)
+	}
+
+	public, private := instanceNetwork.IPv4.Public, instanceNetwork.IPv4.Private
+
+	if len(public) > 0 {
+		d.Set("ip_address", public[0].Address)
+
+		d.SetConnInfo(map[string]string{
+			"type": "ssh",
+			"host": public[0].Address,
+		})
+	}
+
+	if len(private) > 0 {
+		d.Set("private_networking", true)
+		d.Set("private_ip_address", private[0].Address)
+	} else {
+		d.Set("private_networking", false)
+	}
+
+	return nil
+}
+
 func resourceLinodeLinodeCreate(d *schema.ResourceData, meta interface{}) error {
 	waitSeconds := 180
 	client, ok := meta.(linodego.Client)
@@ -335,8 +811,20 @@ func resourceLinodeLinodeCreate(d *schema.ResourceData, meta interface{}) error
 		Label:  d.Get("name").(string),
 		Group:  d.Get("group").(string),
 	}
-	instance, err := client.CreateInstance(&createOpts)
-	if err != nil {
+	// A backup is restored to the new instance, which is booted once the restore finishes
+	backupID := d.Get("backup_id").(int)
+	if backupID != 0 {
+		createOpts.BackupID = backupID
+		createOpts.Booted = true
+	}
+	var instance *linodego.Instance
+	var err error
+	sourceID := d.Get("source_linode_id").(int)
+	if sourceID != 0 {
+		if instance, err = cloneLinodeInstance(&client, sourceID, d); err != nil {
+			return err
+		}
+	} else if instance, err = client.CreateInstance(&createOpts); err != nil {
 		return fmt.Errorf("Failed to create a Linode instance in region %s of type %d because %s", d.Get("region"), d.Get("type"), err)
 	}
 	d.SetId(fmt.Sprintf("%d", instance.ID))
@@ -346,82 +834,138 @@ func resourceLinodeLinodeCreate(d *schema.ResourceData, meta interface{}) error
 	d.SetPartial("type")
 	d.SetPartial("name")
 	d.SetPartial("group")
-
-	swapSize := 0
-	var swapDisk *linodego.InstanceDisk
-
-	// Create the Swap Partition
-	_, err = client.WaitForEventFinished(instance.ID, linodego.EntityLinode, linodego.ActionLinodeCreate, *instance.Created, waitSeconds)
-	if swapSize = d.Get("swap_size").(int); swapSize > 0 {
-		swapOpts := linodego.InstanceDiskCreateOptions{
-			Label:      "linode" + strconv.Itoa(instance.ID) + "-swap",
-			Filesystem: "swap",
-			Size:       swapSize,
+	d.SetPartial("backup_id")
+	d.SetPartial("source_linode_id")
+	d.SetPartial("source_disks")
+	d.SetPartial("source_configs")
+
+	if sourceID != 0 {
+		// The clone event belongs to the source instance
+		if _, err = client.WaitForEventFinished(sourceID, linodego.EntityLinode, linodego.ActionLinodeClone, *instance.Created, WaitTimeout); err != nil {
+			return fmt.Errorf("Failed waiting for Linode instance %d to be cloned to Linode instance %d because %s", sourceID, instance.ID, err)
+		}
+		if err = waitForInstanceStatus(&client, instance.ID, InstanceOffline, WaitTimeout); err != nil {
+			return fmt.Errorf("Timed-out waiting for Linode instance %d to be cloned because %s", instance.ID, err)
 		}
+	} else {
+		_, err = client.WaitForEventFinished(instance.ID, linodego.EntityLinode, linodego.ActionLinodeCreate, *instance.Created, waitSeconds)
+	}
 
-		swapDisk, err = client.CreateInstanceDisk(instance.ID, swapOpts)
+	if err = updateLinodeBackups(&client, instance.ID, d); err != nil {
+		return err
+	}
+	if err = updateLinodeAlerts(&client, instance, d); err != nil {
+		return err
+	}
 
-		if err != nil {
-			return fmt.Errorf("Failed to create Linode instance %d swap disk because %s", instance.ID, err)
-		}
+	if backupID != 0 {
+		return createLinodeFromBackup(&client, instance, d, meta)
+	}
+	if sourceID != 0 {
+		return adoptLinodeConfig(&client, instance, d, meta, fmt.Sprintf("Linode instance %d cloned from Linode instance %d", instance.ID, sourceID))
+	}
 
-		_, err := client.WaitForEventFinished(instance.ID, linodego.EntityLinode, linodego.ActionDiskCreate, swapDisk.Created, waitSeconds)
-		if err != nil {
-			return fmt.Errorf("Failed waiting for Linode instance %d swap disk because %s", swapDisk.ID, err)
+	var configDevices *linodego.InstanceConfigDeviceMap
+	if disks := d.Get("disk").([]interface{}); len(disks) > 0 {
+		if configDevices, err = createLinodeDeclaredDisks(&client, instance.ID, disks); err != nil {
+			return err
 		}
+		d.SetPartial("disk")
+	} else {
+		swapSize := 0
+		var swapDisk *linodego.InstanceDisk
+
+		// Create the Swap Partition
+		if swapSize = d.Get("swap_size").(int); swapSize > 0 {
+			swapOpts := linodego.InstanceDiskCreateOptions{
+				Label:      "linode" + strconv.Itoa(instance.ID) + "-swap",
+				Filesystem: "swap",
+				Size:       swapSize,
+			}
 
-	}
-	d.SetPartial("swap_size")
+			swapDisk, err = client.CreateInstanceDisk(instance.ID, swapOpts)
 
-	// Create the storage Partition
+			if err != nil {
+				return fmt.Errorf("Failed to create Linode instance %d swap disk because %s", instance.ID, err)
+			}
 
-	storageSize := d.Get("storage").(int)
+			_, err := client.WaitForEventFinished(instance.ID, linodego.EntityLinode, linodego.ActionDiskCreate, swapDisk.Created, waitSeconds)
+			if err != nil {
+				return fmt.Errorf("Failed waiting for Linode instance %d swap disk because %s", swapDisk.ID, err)
+			}
 
-	if !ok || storageSize == 0 {
-		storageSize = instance.Specs.Disk - d.Get("swap_size").(int)
-	}
+		}
+		d.SetPartial("swap_size")
 
-	diskOpts := linodego.InstanceDiskCreateOptions{
-		Label:      "linode" + strconv.Itoa(instance.ID) + "-root",
-		Filesystem: "ext4",
-		Size:       storageSize,
-	}
+		// Create the storage Partition
+
+		storageSize := d.Get("storage").(int)
+
+		if storageSize == 0 {
+			storageSize = instance.Specs.Disk - d.Get("swap_size").(int)
+		}
 
-	if image, ok := d.GetOk("image"); ok {
-		diskOpts.Image = image.(string)
+		diskOpts := linodego.InstanceDiskCreateOptions{
+			Label:      "linode" + strconv.Itoa(instance.ID) + "-root",
+			Filesystem: "ext4",
+			Size:       storageSize,
+		}
 
-		diskOpts.RootPass = d.Get("root_password").(string)
+		if image, ok := d.GetOk("image"); ok {
+			diskOpts.Image = image.(string)
 
-		if sshKeys, ok := d.GetOk("ssh_key"); ok {
-			if sshKeysArr, ok := sshKeys.([]interface{}); ok {
-				diskOpts.AuthorizedKeys = make([]string, len(sshKeysArr))
-				for k, v := range sshKeys.([]interface{}) {
-					if val, ok := v.(string); ok {
-						diskOpts.AuthorizedKeys[k] = val
+			// A generated password is kept in the state, unlike the hash of a given one, so it can be used
+			rootPass := d.Get("root_password").(string)
+			if rootPass == "" {
+				if rootPass, err = generateLinodeRootPassword(); err != nil {
+					return fmt.Errorf("Failed to generate a root password for Linode instance %d because %s", instance.ID, err)
+				}
+				d.Set("root_password", rootPass)
+			}
+			diskOpts.RootPass = rootPass
+
+			if sshKeys, ok := d.GetOk("ssh_key"); ok {
+				if sshKeysArr, ok := sshKeys.([]interface{}); ok {
+					diskOpts.AuthorizedKeys = make([]string, len(sshKeysArr))
+					for k, v := range sshKeys.([]interface{}) {
+						if val, ok := v.(string); ok {
+							diskOpts.AuthorizedKeys[k] = val
+						}
 					}
 				}
 			}
 		}
-	}
 
-	storageDisk, err := client.CreateInstanceDisk(instance.ID, diskOpts)
-	if err != nil {
-		return fmt.Errorf("Failed to create Linode instance %d root disk because %s", instance.ID, err)
-	}
+		if stackscriptID, ok := d.GetOk("stackscript_id"); ok {
+			diskOpts.StackscriptID = stackscriptID.(int)
+			diskOpts.StackscriptData = make(map[string]string)
+			for name, value := range d.Get("stackscript_data").(map[string]interface{}) {
+				diskOpts.StackscriptData[name] = value.(string)
+			}
+		}
 
-	_, err = client.WaitForEventFinished(instance.ID, linodego.EntityLinode, linodego.ActionDiskCreate, storageDisk.Created, waitSeconds)
-	if err != nil {
-		return fmt.Errorf("Failed waiting for Linode instance %d root disk because %s", storageDisk.ID, err)
-	}
+		storageDisk, err := client.CreateInstanceDisk(instance.ID, diskOpts)
+		if err != nil {
+			return fmt.Errorf("Failed to create Linode instance %d root disk because %s", instance.ID, err)
+		}
 
-	d.SetPartial("image")
-	d.SetPartial("root_password")
-	d.SetPartial("ssh_key")
+		_, err = client.WaitForEventFinished(instance.ID, linodego.EntityLinode, linodego.ActionDiskCreate, storageDisk.Created, waitSeconds)
+		if err != nil {
+			return fmt.Errorf("Failed waiting for Linode instance %d root disk because %s", storageDisk.ID, err)
+		}
 
-	if err != nil {
-		return fmt.Errorf("Failed to create Linode instance %d disk because %s", instance.ID, err)
+		d.SetPartial("image")
+		d.SetPartial("root_password")
+		d.SetPartial("ssh_key")
+		d.SetPartial("storage")
+
+		configDevices = &linodego.InstanceConfigDeviceMap{
+			SDA: &linodego.InstanceConfigDevice{DiskID: storageDisk.ID},
+		}
+		if swapDisk != nil {
+			configDevices.SDB = &linodego.InstanceConfigDevice{DiskID: swapDisk.ID}
+		}
 	}
-	d.SetPartial("storage")
 
 	if d.Get("private_networking").(bool) {
 		resp, err := client.AddInstanceIPAddress(instance.ID, false)
@@ -432,44 +976,167 @@ func resourceLinodeLinodeCreate(d *schema.ResourceData, meta interface{}) error
 		d.SetPartial("private_ip_address")
 	}
 
-	configDevices := &linodego.InstanceConfigDeviceMap{
-		SDA: &linodego.InstanceConfigDevice{DiskID: storageDisk.ID},
-	}
+	var config *linodego.InstanceConfig
+	if configBlocks := d.Get("config").([]interface{}); len(configBlocks) > 0 {
+		// Configs without devices get the root and swap disks deployed from image, as the config created from kernel does
+		var defaultDevices *linodego.InstanceConfigDeviceMap
+		if d.Get("image").(string) != "" {
+			defaultDevices = configDevices
+		}
+		if config, err = createLinodeDeclaredConfigs(&client, instance.ID, configBlocks, d.Get("boot_config_label").(string), defaultDevices); err != nil {
+			return err
+		}
+		d.SetPartial("config")
+	} else {
+		configOpts := linodego.InstanceConfigCreateOptions{
+			Label:  fmt.Sprintf("linode%d-config", instance.ID),
+			Kernel: d.Get("kernel").(string),
+			// RootDevice: "/dev/sda",
+			// RunLevel:   "default",
+			// VirtMode:   "paravirt",
+			Helpers: &linodego.InstanceConfigHelpers{
+				Distro:  d.Get("helper_distro").(bool),
+				Network: d.Get("helper_network").(bool),
+			},
+			Devices: configDevices,
+		}
 
-	if swapDisk.ID > 0 {
-		configDevices.SDB = &linodego.InstanceConfigDevice{DiskID: swapDisk.ID}
+		if config, err = client.CreateInstanceConfig(instance.ID, configOpts); err != nil {
+			return fmt.Errorf("Failed to create Linode instance %d config because %s", instance.ID, err)
+		}
+
+		d.SetPartial("helper_network")
+		d.SetPartial("helper_distro")
 	}
+	d.Set("boot_config_label", config.Label)
+	d.SetPartial("boot_config_label")
 
-	configOpts := linodego.InstanceConfigCreateOptions{
-		Label:  fmt.Sprintf("linode%d-config", instance.ID),
-		Kernel: d.Get("kernel").(string),
-		// RootDevice: "/dev/sda",
-		// RunLevel:   "default",
-		// VirtMode:   "paravirt",
-		Helpers: &linodego.InstanceConfigHelpers{
-			Distro:  d.Get("helper_distro").(bool),
-			Network: d.Get("helper_network").(bool),
-		},
-		Devices: configDevices,
+	if d.Get("booted").(bool) {
+		booted, err := client.BootInstance(instance.ID, config.ID)
+		if !booted {
+			return fmt.Errorf("Failed to boot Linode instance %d because %s", instance.ID, err)
+		}
+
+		if err = waitForInstanceStatus(&client, instance.ID, InstanceRunning, WaitTimeout); err != nil {
+			return fmt.Errorf("Timed-out waiting for Linode instance %d to boot because %s", instance.ID, err)
+		}
+		if rescue := d.Get("rescue").([]interface{}); len(rescue) > 0 {
+			if err = rescueLinodeInstance(&client, instance.ID, rescue); err != nil {
+				return err
+			}
+		}
+	}
+	d.Partial(false)
+
+	return resourceLinodeLinodeRead(d, meta)
+}
+
+// createLinodeFromBackup finishes creating a Linode instance restored from a backup, which brings its own disks and
+// configs. The restored instance is shut down while its config is updated.
+func createLinodeFromBackup(client *linodego.Client, instance *linodego.Instance, d *schema.ResourceData, meta interface{}) error {
+	if err := waitForInstanceStatus(client, instance.ID, InstanceRunning, WaitTimeout); err != nil {
+		return fmt.Errorf("Timed-out waiting for Linode instance %d to be restored from backup %d because %s", instance.ID, d.Get("backup_id"), err)
+	}
+	if _, err := shutdownInstanceIfRunning(client, instance.ID); err != nil {
+		return err
+	}
+
+	return adoptLinodeConfig(client, instance, d, meta, fmt.Sprintf("Linode instance %d restored from backup %d", instance.ID, d.Get("backup_id")))
+}
+
+// cloneLinodeInstance clones the disks and configs named in source_disks and source_configs, or all of them, of a
+// Linode instance to a new instance
+func cloneLinodeInstance(client *linodego.Client, sourceID int, d *schema.ResourceData) (*linodego.Instance, error) {
+	cloneOpts := &linodego.InstanceCloneOptions{
+		Region: d.Get("region").(string),
+		Type:   d.Get("type").(string),
+		Label:  d.Get("name").(string),
+		Group:  d.Get("group").(string),
 	}
 
-	config, err := client.CreateInstanceConfig(instance.ID, configOpts)
+	if labels := d.Get("source_disks").([]interface{}); len(labels) > 0 {
+		diskIDs, err := linodeDiskIDs(client, sourceID)
+		if err != nil {
+			return nil, err
+		}
+		for _, label := range labels {
+			diskID, ok := diskIDs[label.(string)]
+			if !ok {
+				return nil, fmt.Errorf("Linode instance %d has no disk %s to clone", sourceID, label)
+			}
+			cloneOpts.Disks = append(cloneOpts.Disks, diskID)
+		}
+	}
+
+	if labels := d.Get("source_configs").([]interface{}); len(labels) > 0 {
+		configs, err := client.ListInstanceConfigs(sourceID, nil)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to get the configs of Linode instance %d because %s", sourceID, err)
+		}
+		for _, label := range labels {
+			config := findLinodeConfig(configs, label.(string))
+			if config == nil {
+				return nil, fmt.Errorf("Linode instance %d has no config %s to clone", sourceID, label)
+			}
+			cloneOpts.Configs = append(cloneOpts.Configs, config.ID)
+		}
+	}
+
+	instance, err := client.CloneInstance(sourceID, cloneOpts)
 	if err != nil {
-		return fmt.Errorf("Failed to create Linode instance %d config because %s", instance.ID, err)
+		return nil, fmt.Errorf("Failed to clone Linode instance %d because %s", sourceID, err)
 	}
+	return instance, nil
+}
 
-	d.SetPartial("helper_network")
-	d.SetPartial("helper_distro")
+// adoptLinodeConfig finishes creating an offline Linode instance that brings its own disks and configs. It applies
+// kernel and the helper settings to the config to boot, and boots the instance if booted is set. origin describes
+// the instance in errors.
+func adoptLinodeConfig(client *linodego.Client, instance *linodego.Instance, d *schema.ResourceData, meta interface{}, origin string) error {
+	if d.Get("private_networking").(bool) {
+		resp, err := client.AddInstanceIPAddress(instance.ID, false)
+		if err != nil {
+			return fmt.Errorf("Failed to add a private ip address to Linode instance %d because %s", instance.ID, err)
+		}
+		d.Set("private_ip_address", resp.Address)
+		d.SetPartial("private_ip_address")
+	}
 
-	booted, err := client.BootInstance(instance.ID, config.ID)
-	if !booted {
-		return fmt.Errorf("Failed to boot Linode instance %d because %s", instance.ID, err)
+	configs, err := client.ListInstanceConfigs(instance.ID, nil)
+	if err != nil {
+		return fmt.Errorf("Failed to get the configs of Linode instance %d because %s", instance.ID, err)
+	}
+	config := findLinodeConfig(configs, d.Get("boot_config_label").(string), fmt.Sprintf("linode%d-config", instance.ID))
+	if config == nil {
+		return fmt.Errorf("%s has no config", origin)
 	}
 
-	d.Partial(false)
-	if err = waitForInstanceStatus(&client, instance.ID, InstanceRunning, WaitTimeout); err != nil {
-		return fmt.Errorf("Timed-out waiting for Linode instance %d to boot because %s", instance.ID, err)
+	updateOpts := config.GetUpdateOptions()
+	updateOpts.Kernel = d.Get("kernel").(string)
+	updateOpts.Helpers = &linodego.InstanceConfigHelpers{
+		Distro:  d.Get("helper_distro").(bool),
+		Network: d.Get("helper_network").(bool),
+	}
+	if config, err = client.UpdateInstanceConfig(instance.ID, config.ID, updateOpts); err != nil {
+		return fmt.Errorf("Failed to update Linode instance %d config because %s", instance.ID, err)
+	}
+	d.Set("boot_config_label", config.Label)
+	d.SetPartial("boot_config_label")
+	d.SetPartial("kernel")
+	d.SetPartial("helper_distro")
+	d.SetPartial("helper_network")
+
+	if d.Get("booted").(bool) {
+		if err = bootInstance(client, instance.ID, config.ID); err != nil {
+			return err
+		}
+		if rescue := d.Get("rescue").([]interface{}); len(rescue) > 0 {
+			if err = rescueLinodeInstance(client, instance.ID, rescue); err != nil {
+				return err
+			}
+		}
 	}
+	d.Partial(false)
 
 	return resourceLinodeLinodeRead(d, meta)
 }
@@ -496,8 +1163,41 @@ func resourceLinodeLinodeUpdate(d *schema.ResourceData, meta interface{}) error
 		d.SetPartial("name")
 	}
 
+	if err = updateLinodeBackups(&client, instance.ID, d); err != nil {
+		return err
+	}
+	if err = updateLinodeAlerts(&client, instance, d); err != nil {
+		return err
+	}
+
+	if d.HasChange("region") {
+		if err = migrateLinodeInstance(&client, instance, d.Get("region").(string)); err != nil {
+			return err
+		}
+		if err = readLinodeIPAddresses(&client, instance.ID, d); err != nil {
+			return err
+		}
+		d.SetPartial("region")
+	}
+
 	rebootInstance := false
 
+	// Declared disks must shrink to fit a smaller type before it is resized, and may grow into a larger type after
+	declaredDisksFirst := false
+	if d.HasChange("type") && d.HasChange("disk") {
+		targetType, err := getType(&client, d.Get("type").(string))
+		if err != nil {
+			return fmt.Errorf("Failed to find the instance type %s", d.Get("type"))
+		}
+		declaredDisksFirst = targetType.Disk < instance.Specs.Disk
+	}
+	if declaredDisksFirst {
+		if err = updateLinodeDeclaredDisks(&client, instance, d); err != nil {
+			return err
+		}
+		d.SetPartial("disk")
+	}
+
 	if d.HasChange("type") {
 		err = changeLinodeSize(&client, instance, d)
 		if err != nil {
@@ -521,42 +1221,95 @@ func resourceLinodeLinodeUpdate(d *schema.ResourceData, meta interface{}) error
 		rebootInstance = true
 	}
 
-	configs, err := client.ListInstanceConfigs(int(id), nil)
-	if err != nil {
-		return fmt.Errorf("Failed to fetch the config for linode %d because %s", id, err)
-	}
-	if len(configs) != 1 {
-		return fmt.Errorf("Linode %d has an incorrect number of configs %d, this plugin can only handle 1", id, len(configs))
-	}
-	config := configs[0].GetUpdateOptions()
-	updateConfig := false
-	if d.HasChange("helper_distro") {
-		updateConfig = true
-		config.Helpers.Distro = d.Get("helper_distro").(bool)
+	if d.HasChange("disk") && !declaredDisksFirst {
+		if err = updateLinodeDeclaredDisks(&client, instance, d); err != nil {
+			return err
+		}
+		d.SetPartial("disk")
 	}
-	if d.HasChange("helper_network") {
-		updateConfig = true
-		config.Helpers.Network = d.Get("helper_network").(bool)
+
+	if d.HasChange("image") || d.HasChange("ssh_key") {
+		// The rebuilt instance is deployed with the current kernel, helpers, networking and root_password
+		if err = rebuildLinodeInstance(&client, instance, d); err != nil {
+			return err
+		}
+		for _, key := range linodeRebuildKeys {
+			d.SetPartial(key)
+		}
+		d.SetPartial("root_password")
+		if rescue := d.Get("rescue").([]interface{}); d.Get("booted").(bool) && len(rescue) > 0 {
+			if err = rescueLinodeInstance(&client, instance.ID, rescue); err != nil {
+				return err
+			}
+		}
+		d.Partial(false)
+		return nil
 	}
-	if d.HasChange("kernel") {
-		updateConfig = true
-		config.Kernel = d.Get("kernel").(string)
+
+	if d.HasChange("root_password") && d.Get("root_password").(string) != "" {
+		if err = resetLinodeRootPassword(&client, instance.ID, d.Get("root_password").(string)); err != nil {
+			return err
+		}
+		d.SetPartial("root_password")
 	}
 
-	if updateConfig {
-		_, err := client.UpdateInstanceConfig(instance.ID, configs[0].ID, config)
-		if err != nil {
-			return fmt.Errorf("Failed to update Linode %d config because %s", instance.ID, err)
+	if d.HasChange("swap_size") && d.Get("image").(string) != "" {
+		if err = updateLinodeSwapSize(&client, instance, d); err != nil {
+			return err
 		}
-		d.SetPartial("helper_distro")
-		d.SetPartial("helper_network")
-		d.SetPartial("kernel")
+		d.SetPartial("swap_size")
+	}
 
-		rebootInstance = true
+	var bootConfig *linodego.InstanceConfig
+	configChanged := false
+	if len(d.Get("config").([]interface{})) > 0 {
+		if bootConfig, configChanged, err = updateLinodeDeclaredConfigs(&client, instance.ID, d); err != nil {
+			return err
+		}
+		d.SetPartial("config")
+		d.SetPartial("boot_config_label")
+	} else {
+		// Removing the last config block boots the config created from kernel again
+		removedConfigs := d.HasChange("config")
+		if removedConfigs {
+			if err = removeLinodeDeclaredConfigs(&client, instance, d); err != nil {
+				return err
+			}
+			d.SetPartial("config")
+		}
+		if bootConfig, configChanged, err = updateLinodeConfig(&client, instance.ID, d); err != nil {
+			return err
+		}
+		if removedConfigs {
+			configChanged = true
+			d.Set("boot_config_label", bootConfig.Label)
+			d.SetPartial("boot_config_label")
+		}
 	}
 
-	if rebootInstance {
-		_, err = client.RebootInstance(instance.ID, configs[0].ID)
+	booted := d.Get("booted").(bool)
+	rescue := d.Get("rescue").([]interface{})
+	// Leaving Rescue Mode reboots the instance with its config
+	leaveRescue := d.HasChange("rescue") && len(rescue) == 0
+	// Resizing, migrating and resetting the root password boot the instance with its config as well
+	restarted := d.HasChange("type") || d.HasChange("region") || d.HasChange("swap_size") || d.HasChange("disk") ||
+		d.HasChange("root_password")
+	switch {
+	case d.HasChange("booted") && booted:
+		configID := 0
+		if bootConfig != nil {
+			configID = bootConfig.ID
+		}
+		if err = bootInstance(&client, instance.ID, configID); err != nil {
+			return err
+		}
+		restarted = true
+	case d.HasChange("booted"):
+		if _, err = shutdownInstanceIfRunning(&client, instance.ID); err != nil {
+			return err
+		}
+	case booted && (rebootInstance || configChanged || leaveRescue) && bootConfig != nil:
+		_, err = client.RebootInstance(instance.ID, bootConfig.ID)
 		if err != nil {
 			return fmt.Errorf("Failed to reboot Linode instance %d because %s", instance.ID, err)
 		}
@@ -564,104 +1317,527 @@ func resourceLinodeLinodeUpdate(d *schema.ResourceData, meta interface{}) error
 		if err != nil {
 			return fmt.Errorf("Failed while waiting for Linode instance %d to finish rebooting because %s", instance.ID, err)
 		}
+		restarted = true
+	}
+	d.SetPartial("booted")
+
+	// A restarted instance is booted into Rescue Mode again
+	if booted && len(rescue) > 0 && (d.HasChange("rescue") || restarted) {
+		if err = rescueLinodeInstance(&client, instance.ID, rescue); err != nil {
+			return err
+		}
 	}
+	d.SetPartial("rescue")
 
 	return nil // resourceLinodeLinodeRead(d, meta)
 }
 
-func resourceLinodeLinodeDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(linodego.Client)
-	id, err := strconv.ParseInt(d.Id(), 10, 64)
+// resetLinodeRootPassword sets the password of the root user of the root disk of a Linode instance. The instance is
+// shut down while the password is reset, and booted again afterwards if it was running.
+func resetLinodeRootPassword(client *linodego.Client, linodeID int, password string) error {
+	disks, err := client.ListInstanceDisks(linodeID, nil)
 	if err != nil {
-		return fmt.Errorf("Failed to parse linode id %s as int", d.Id())
+		return fmt.Errorf("Failed to get the disks for the Linode instance %d because %s", linodeID, err)
 	}
-	err = client.DeleteInstance(int(id))
+	rootDisk, _ := findLinodeRootAndSwapDisks(disks)
+	if rootDisk == nil {
+		return fmt.Errorf("Failed to find the root disk of Linode instance %d", linodeID)
+	}
+
+	wasRunning, err := shutdownInstanceIfRunning(client, linodeID)
 	if err != nil {
-		return fmt.Errorf("Failed to delete Linode instance %d because %s", id, err)
+		return err
+	}
+	if _, err = client.PasswordResetInstanceDisk(linodeID, rootDisk.ID, password); err != nil {
+		return fmt.Errorf("Failed to reset the root password of Linode instance %d because %s", linodeID, err)
+	}
+	if _, err = client.WaitForEventFinished(linodeID, linodego.EntityLinode, linodego.ActionPasswordReset, rootDisk.Updated, WaitTimeout); err != nil {
+		return fmt.Errorf("Failed waiting for the root password of Linode instance %d to be reset because %s", linodeID, err)
+	}
+
+	if wasRunning {
+		return bootInstance(client, linodeID, 0)
 	}
 	return nil
 }
 
-// getKernel gets the kernel from the id of the kernel
-func getKernel(client *linodego.Client, kernelID string) (*linodego.LinodeKernel, error) {
-	if kernelList == nil {
-		if err := getKernelList(client); err != nil {
-			return nil, err
-		}
+// rescueLinodeInstance reboots a Linode instance into Rescue Mode with the devices of the rescue block attached
+func rescueLinodeInstance(client *linodego.Client, linodeID int, rescue []interface{}) error {
+	diskIDs, err := linodeDiskIDs(client, linodeID)
+	if err != nil {
+		return err
+	}
+	devices, err := expandLinodeConfigDevices(rescue[0].(map[string]interface{})["devices"].([]interface{}), diskIDs)
+	if err != nil {
+		return err
 	}
 
-	if t, ok := kernelListMap[kernelID]; ok {
-		return t, nil
+	instance, err := client.GetInstance(linodeID)
+	if err != nil {
+		return fmt.Errorf("Failed to fetch data about Linode instance %d because %s", linodeID, err)
 	}
-	return nil, fmt.Errorf("Unable to find Linode Kernel %s", kernelID)
+	if _, err = client.RescueInstance(linodeID, linodego.InstanceRescueOptions{Devices: *devices}); err != nil {
+		return fmt.Errorf("Failed to boot Linode instance %d into Rescue Mode because %s", linodeID, err)
+	}
+	if _, err = client.WaitForEventFinished(linodeID, linodego.EntityLinode, linodego.ActionLinodeBoot, *instance.Updated, WaitTimeout); err != nil {
+		return fmt.Errorf("Failed while waiting for Linode instance %d to boot into Rescue Mode because %s", linodeID, err)
+	}
+	return nil
 }
 
-// getKernelList populates kernelList with the available kernels. kernelList is used to reduce the number of api
-// requests required as it is unlikely that the available kernels will change during a single terraform run.
-func getKernelList(client *linodego.Client) error {
-	var err error
-	if kernelList == nil {
-		if kernelList, err = client.ListKernels(nil); err != nil {
-			return err
-		}
+// updateLinodeConfig applies the kernel and helper settings to the config of a Linode instance without declared
+// configs, which is the config named by boot_config_label, the config Terraform created, or the first config.
+// It returns the config and whether it changed, so the instance must be rebooted for changes to take effect.
+func updateLinodeConfig(client *linodego.Client, linodeID int, d *schema.ResourceData) (*linodego.InstanceConfig, bool, error) {
+	configs, err := client.ListInstanceConfigs(linodeID, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("Failed to fetch the config for linode %d because %s", linodeID, err)
+	}
+	current := findLinodeConfig(configs, d.Get("boot_config_label").(string), fmt.Sprintf("linode%d-config", linodeID))
+	if current == nil {
+		return nil, false, fmt.Errorf("Linode %d has no config", linodeID)
+	}
+	config := current.GetUpdateOptions()
+	if config.Helpers == nil {
+		config.Helpers = &linodego.InstanceConfigHelpers{}
+	}
+	updateConfig := false
+	if d.HasChange("helper_distro") {
+		updateConfig = true
+		config.Helpers.Distro = d.Get("helper_distro").(bool)
+	}
+	if d.HasChange("helper_network") {
+		updateConfig = true
+		config.Helpers.Network = d.Get("helper_network").(bool)
+	}
+	if d.HasChange("kernel") {
+		updateConfig = true
+		config.Kernel = d.Get("kernel").(string)
+	}
 
-		kernelListMap = make(map[string]*linodego.LinodeKernel)
-		for _, t := range kernelList {
-			kernelListMap[t.ID] = t
+	if updateConfig {
+		if current, err = client.UpdateInstanceConfig(linodeID, current.ID, config); err != nil {
+			return nil, false, fmt.Errorf("Failed to update Linode %d config because %s", linodeID, err)
 		}
+		d.SetPartial("helper_distro")
+		d.SetPartial("helper_network")
+		d.SetPartial("kernel")
 	}
 
-	return nil
+	return current, updateConfig, nil
 }
 
-// getRegion gets the region from the id of the region
-func getRegion(client *linodego.Client, regionID string) (*linodego.Region, error) {
-	if regionList == nil {
-		if err := getRegionList(client); err != nil {
-			return nil, err
+// linodeRebuildKeys are the attributes which are deployed to the disks of a Linode instance. Changing them replaces
+// the instance, unless rebuild_in_place is set. root_password is reset in place instead.
+var linodeRebuildKeys = []string{"image", "ssh_key"}
+
+func resourceLinodeLinodeCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" && d.HasChange("region") {
+		if !d.Get("migrate_on_region_change").(bool) {
+			if err := d.ForceNew("region"); err != nil {
+				return err
+			}
+		} else {
+			// A migrated instance is assigned addresses in its new region
+			for _, key := range []string{"ip_address", "private_ip_address"} {
+				if err := d.SetNewComputed(key); err != nil {
+					return err
+				}
+			}
 		}
 	}
 
-	if t, ok := regionListMap[regionID]; ok {
-		return t, nil
+	if d.Id() != "" {
+		if !d.Get("rebuild_in_place").(bool) {
+			for _, key := range linodeRebuildKeys {
+				if d.HasChange(key) {
+					if err := d.ForceNew(key); err != nil {
+						return err
+					}
+				}
+			}
+		} else if err := customizeDiffLinodeRebuild(d); err != nil {
+			return err
+		}
 	}
-	return nil, fmt.Errorf("Unable to find Linode Region %s", regionID)
+
+	if err := customizeDiffLinodeBackups(d); err != nil {
+		return err
+	}
+	if err := customizeDiffLinodeRescue(d); err != nil {
+		return err
+	}
+	if err := customizeDiffLinodeDisks(d, meta); err != nil {
+		return err
+	}
+	if err := customizeDiffLinodeConfigs(d); err != nil {
+		return err
+	}
+
+	return customizeDiffLinodeStackscriptData(d, meta)
 }
 
-// getRegionList populates regionList with the available regions. regionList is used to reduce the number of api
-// requests required as it is unlikely that the available regions will change during a single terraform run.
-func getRegionList(client *linodego.Client) error {
-	if regionList == nil {
-		var err error
-		if regionList, err = client.ListRegions(nil); err != nil {
-			return err
-		}
+// customizeDiffLinodeBackups requires the Backup service for a backup schedule, and plans the schedule and backups
+// chosen by the Backup service when it is enabled
+func customizeDiffLinodeBackups(d *schema.ResourceDiff) error {
+	if !d.NewValueKnown("backups_enabled") || !d.NewValueKnown("backups_schedule") {
+		return nil
+	}
+	enabled := d.Get("backups_enabled").(bool)
+	if !enabled && d.HasChange("backups_schedule") && len(d.Get("backups_schedule").([]interface{})) > 0 {
+		return fmt.Errorf("backups_schedule requires backups_enabled")
+	}
 
-		regionListMap = make(map[string]*linodego.Region)
-		for _, t := range regionList {
-			regionListMap[t.ID] = t
+	if d.HasChange("backups_enabled") {
+		if enabled && !d.HasChange("backups_schedule") {
+			if err := d.SetNewComputed("backups_schedule"); err != nil {
+				return err
+			}
 		}
+		return d.SetNewComputed("backups")
 	}
-
 	return nil
 }
 
-// getType gets the amount of ram from the plan id
-func getType(client *linodego.Client, typeID string) (*linodego.LinodeType, error) {
-	if typeList == nil {
-		if err := getTypeList(client); err != nil {
-			return nil, err
-		}
+// customizeDiffLinodeRescue checks the devices of the rescue block, which can not use sdh, and requires booted
+func customizeDiffLinodeRescue(d *schema.ResourceDiff) error {
+	if !d.NewValueKnown("rescue") {
+		return nil
+	}
+	rescue := d.Get("rescue").([]interface{})
+	if len(rescue) == 0 || rescue[0] == nil {
+		return nil
+	}
+	if d.NewValueKnown("booted") && !d.Get("booted").(bool) {
+		return fmt.Errorf("rescue requires booted")
 	}
 
-	if t, ok := typeListMap[typeID]; ok {
-		return t, nil
+	devices := rescue[0].(map[string]interface{})["devices"].([]interface{})
+	if err := validateLinodeConfigDevices(devices); err != nil {
+		return fmt.Errorf("Invalid rescue devices: %s", err)
 	}
-	return nil, fmt.Errorf("Unable to find Linode Type %s", typeID)
+	for _, slots := range devices {
+		if slots != nil && len(slots.(map[string]interface{})["sdh"].([]interface{})) > 0 {
+			return fmt.Errorf("Invalid rescue devices: sdh can not be attached in Rescue Mode")
+		}
+	}
+	return nil
 }
 
-// getTypeList populates typeList and typeListMap. typeList is used to reduce
-//  the number of api requests required as its unlikely that
+// customizeDiffLinodeConfigs requires either a kernel or declared configs, and checks that declared configs have
+// unique labels, boot_config_label names one of them, and their devices name declared disks
+func customizeDiffLinodeConfigs(d *schema.ResourceDiff) error {
+	if !d.NewValueKnown("config") {
+		return nil
+	}
+
+	configs := d.Get("config").([]interface{})
+	if len(configs) == 0 {
+		if d.NewValueKnown("kernel") && d.Get("kernel").(string) == "" {
+			return fmt.Errorf("One of kernel or config must be set")
+		}
+		return nil
+	}
+
+	labels := make(map[string]bool, len(configs))
+	for _, config := range configs {
+		config := config.(map[string]interface{})
+		label := config["label"].(string)
+		if labels[label] {
+			return fmt.Errorf("Config labels must be unique, %s is declared more than once", label)
+		}
+		labels[label] = true
+
+		if err := validateLinodeConfigDevices(config["devices"].([]interface{})); err != nil {
+			return fmt.Errorf("Invalid devices for config %s: %s", label, err)
+		}
+	}
+
+	if label := d.Get("boot_config_label").(string); d.NewValueKnown("boot_config_label") && label != "" && !labels[label] {
+		return fmt.Errorf("boot_config_label %s does not name a declared config", label)
+	}
+
+	if !d.NewValueKnown("disk") || d.Get("image").(string) != "" {
+		return nil
+	}
+	diskLabels := linodeDiskLabels(d.Get("disk").([]interface{}))
+	for _, config := range configs {
+		config := config.(map[string]interface{})
+		for _, devices := range config["devices"].([]interface{}) {
+			if devices == nil {
+				continue
+			}
+			for _, slot := range configDeviceSlots {
+				for _, device := range devices.(map[string]interface{})[slot].([]interface{}) {
+					if device == nil {
+						continue
+					}
+					if label := device.(map[string]interface{})["disk_label"].(string); label != "" && !diskLabels[label] {
+						return fmt.Errorf("Device %s of config %s names disk %s, which is not declared", slot, config["label"], label)
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// customizeDiffLinodeDisks requires either an image or declared disks, and checks that declared disks have unique
+// labels, passwords for their images, and fit in the storage of the Linode type
+func customizeDiffLinodeDisks(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("image") || !d.NewValueKnown("disk") {
+		return nil
+	}
+
+	if d.Get("image").(string) != "" {
+		return customizeDiffLinodeImageResize(d, meta)
+	}
+
+	disks := d.Get("disk").([]interface{})
+	if len(disks) == 0 {
+		if d.Get("backup_id").(int) != 0 || d.Get("source_linode_id").(int) != 0 {
+			return nil
+		}
+		return fmt.Errorf("One of image, disk, backup_id or source_linode_id must be set")
+	}
+
+	labels := make(map[string]bool, len(disks))
+	totalSize := 0
+	for _, disk := range disks {
+		disk := disk.(map[string]interface{})
+		label := disk["label"].(string)
+		if labels[label] {
+			return fmt.Errorf("Disk labels must be unique, %s is declared more than once", label)
+		}
+		labels[label] = true
+		if disk["image"].(string) != "" && disk["root_password"].(string) == "" {
+			return fmt.Errorf("Disk %s requires a root_password to deploy image %s", label, disk["image"])
+		}
+		totalSize += disk["size"].(int)
+	}
+
+	// Disks can only be deployed again by deleting them, so that must be allowed explicitly for each disk
+	if d.Id() != "" && d.HasChange("disk") {
+		o, _ := d.GetChange("disk")
+		for i, match := range matchLinodeDisks(o.([]interface{}), disks) {
+			disk := disks[i].(map[string]interface{})
+			if match != nil && !sameLinodeDiskContents(match, disk) && !disk["allow_redeploy"].(bool) {
+				return fmt.Errorf("Changing the filesystem, image or ssh_key of disk %s deletes the disk and creates it again, losing its data. Set allow_redeploy on the disk to allow it", disk["label"])
+			}
+		}
+	}
+
+	if !d.NewValueKnown("type") || (!d.HasChange("disk") && !d.HasChange("type")) {
+		return nil
+	}
+	client := meta.(linodego.Client)
+	linodeType, err := getType(&client, d.Get("type").(string))
+	if err != nil {
+		return fmt.Errorf("Failed to find the Linode type %s because %s", d.Get("type"), err)
+	}
+	if totalSize > linodeType.Disk {
+		return fmt.Errorf("The disks total %d MB, more than the %d MB of storage of Linode type %s", totalSize, linodeType.Disk, linodeType.ID)
+	}
+
+	return nil
+}
+
+// customizeDiffLinodeImageResize checks that the disks of a Linode deployed from an image fit the storage of a new
+// type. Disks that are too large are shrunk automatically, but only by shrinking the largest disk.
+func customizeDiffLinodeImageResize(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.HasChange("type") || !d.NewValueKnown("type") {
+		return nil
+	}
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("Failed to parse Linode ID %s as int because %s", d.Id(), err)
+	}
+
+	client := meta.(linodego.Client)
+	targetType, err := getType(&client, d.Get("type").(string))
+	if err != nil {
+		return fmt.Errorf("Failed to find the Linode type %s because %s", d.Get("type"), err)
+	}
+	totalDiskSize, err := getTotalDiskSize(&client, int(id))
+	if err != nil {
+		return fmt.Errorf("Failed to get the disks for the Linode instance %d because %s", id, err)
+	}
+	if totalDiskSize <= targetType.Disk {
+		return nil
+	}
+
+	_, biggestDiskSize, err := getBiggestDisk(&client, int(id))
+	if err != nil {
+		return fmt.Errorf("Failed to get the disks for the Linode instance %d because %s", id, err)
+	}
+	if shrink := totalDiskSize - targetType.Disk; shrink >= biggestDiskSize {
+		return fmt.Errorf("The largest disk of Linode instance %d (%d MB) can not be shrunk by %d MB to fit the %d MB of storage of Linode type %s. Shrink the other disks first", id, biggestDiskSize, shrink, targetType.Disk, targetType.ID)
+	}
+
+	return nil
+}
+
+// customizeDiffLinodeStackscriptData checks the stackscript_data of a Linode against the user-defined fields of its
+// StackScript and plans the default values of the fields that were not given. An existing Linode is only checked again
+// when its stackscript_id or stackscript_data change, so later plans don't fetch the StackScript or replace the Linode
+// because the StackScript was edited since it was deployed.
+func customizeDiffLinodeStackscriptData(d *schema.ResourceDiff, meta interface{}) error {
+	stackscriptID, ok := d.GetOk("stackscript_id")
+	if !ok || !d.NewValueKnown("stackscript_id") || !d.NewValueKnown("stackscript_data") {
+		return nil
+	}
+	if d.Id() != "" && !d.HasChange("stackscript_id") && !d.HasChange("stackscript_data") {
+		return nil
+	}
+
+	client := meta.(linodego.Client)
+	stackscript, err := client.GetStackscript(stackscriptID.(int))
+	if err != nil {
+		return fmt.Errorf("Failed to find Linode StackScript %d because %s", stackscriptID, err)
+	}
+
+	if image := d.Get("image").(string); d.NewValueKnown("image") && !stackscriptSupportsImage(stackscript, image) {
+		return fmt.Errorf("Linode StackScript %d can not be deployed with image %s, expected one of %s", stackscript.ID, image, strings.Join(stackscript.Images, ", "))
+	}
+
+	data := make(map[string]string)
+	for name, value := range d.Get("stackscript_data").(map[string]interface{}) {
+		data[name] = value.(string)
+	}
+
+	udfs := parseStackscriptUDFs(stackscript.Script)
+
+	// Defaults already in the state of an existing Linode were deployed with it, keep them even if the StackScript
+	// has since changed them
+	if d.Id() != "" && !d.HasChange("stackscript_id") {
+		old, _ := d.GetChange("stackscript_data")
+		oldData := old.(map[string]interface{})
+		for _, udf := range udfs {
+			if _, ok := data[udf.Name]; !ok && !udf.Required && oldData[udf.Name] != nil {
+				data[udf.Name] = oldData[udf.Name].(string)
+			}
+		}
+	}
+
+	data, err = expandStackscriptData(udfs, data)
+	if err != nil {
+		return fmt.Errorf("Invalid stackscript_data for Linode StackScript %d: %s", stackscript.ID, err)
+	}
+
+	return d.SetNew("stackscript_data", data)
+}
+
+// customizeDiffLinodeRebuild checks that an in place rebuild has a root_password to deploy. A given root_password is
+// only kept in the state as a hash, so it must change along with the rebuild keys to be sent again.
+func customizeDiffLinodeRebuild(d *schema.ResourceDiff) error {
+	if d.HasChange("root_password") {
+		return nil
+	}
+	for _, key := range linodeRebuildKeys {
+		if !d.HasChange(key) {
+			continue
+		}
+		if rootPass := d.Get("root_password").(string); rootPass == "" || isHashedString(rootPass) {
+			return fmt.Errorf("Changing %s rebuilds Linode instance %s with root_password, which is only stored as a hash. Change root_password along with %s", key, d.Id(), key)
+		}
+	}
+	return nil
+}
+
+func resourceLinodeLinodeDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(linodego.Client)
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("Failed to parse linode id %s as int", d.Id())
+	}
+	err = client.DeleteInstance(int(id))
+	if err != nil {
+		return fmt.Errorf("Failed to delete Linode instance %d because %s", id, err)
+	}
+	return nil
+}
+
+// getKernel gets the kernel from the id of the kernel
+func getKernel(client *linodego.Client, kernelID string) (*linodego.LinodeKernel, error) {
+	if kernelList == nil {
+		if err := getKernelList(client); err != nil {
+			return nil, err
+		}
+	}
+
+	if t, ok := kernelListMap[kernelID]; ok {
+		return t, nil
+	}
+	return nil, fmt.Errorf("Unable to find Linode Kernel %s", kernelID)
+}
+
+// getKernelList populates kernelList with the available kernels. kernelList is used to reduce the number of api
+// requests required as it is unlikely that the available kernels will change during a single terraform run.
+func getKernelList(client *linodego.Client) error {
+	var err error
+	if kernelList == nil {
+		if kernelList, err = client.ListKernels(nil); err != nil {
+			return err
+		}
+
+		kernelListMap = make(map[string]*linodego.LinodeKernel)
+		for _, t := range kernelList {
+			kernelListMap[t.ID] = t
+		}
+	}
+
+	return nil
+}
+
+// getRegion gets the region from the id of the region
+func getRegion(client *linodego.Client, regionID string) (*linodego.Region, error) {
+	if regionList == nil {
+		if err := getRegionList(client); err != nil {
+			return nil, err
+		}
+	}
+
+	if t, ok := regionListMap[regionID]; ok {
+		return t, nil
+	}
+	return nil, fmt.Errorf("Unable to find Linode Region %s", regionID)
+}
+
+// getRegionList populates regionList with the available regions. regionList is used to reduce the number of api
+// requests required as it is unlikely that the available regions will change during a single terraform run.
+func getRegionList(client *linodego.Client) error {
+	if regionList == nil {
+		var err error
+		if regionList, err = client.ListRegions(nil); err != nil {
+			return err
+		}
+
+		regionListMap = make(map[string]*linodego.Region)
+		for _, t := range regionList {
+			regionListMap[t.ID] = t
+		}
+	}
+
+	return nil
+}
+
+// getType gets the amount of ram from the plan id
+func getType(client *linodego.Client, typeID string) (*linodego.LinodeType, error) {
+	if typeList == nil {
+		if err := getTypeList(client); err != nil {
+			return nil, err
+		}
+	}
+
+	if t, ok := typeListMap[typeID]; ok {
+		return t, nil
+	}
+	return nil, fmt.Errorf("Unable to find Linode Type %s", typeID)
+}
+
+// getTypeList populates typeList and typeListMap. typeList is used to reduce
+//  the number of api requests required as its unlikely that
 // the plans will change during a single terraform run.
 func getTypeList(client *linodego.Client) error {
 	if typeList == nil {
@@ -722,12 +1898,77 @@ func rootPasswordState(val interface{}) string {
 	return hashString(val.(string))
 }
 
+// rootPasswordCharacterClasses are the character classes of generated root passwords. Punctuation that needs quoting
+// in shells is left out.
+var rootPasswordCharacterClasses = []string{
+	"abcdefghijklmnopqrstuvwxyz",
+	"ABCDEFGHIJKLMNOPQRSTUVWXYZ",
+	"0123456789",
+	"-_.+@%,:",
+}
+
+// generateLinodeRootPassword returns a random password of 32 characters with at least one character of each class,
+// which meets the complexity rules of the Linode API
+func generateLinodeRootPassword() (string, error) {
+	const length = 32
+	all := strings.Join(rootPasswordCharacterClasses, "")
+	password := make([]byte, length)
+	for i := range password {
+		chars := all
+		if i < len(rootPasswordCharacterClasses) {
+			chars = rootPasswordCharacterClasses[i]
+		}
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(chars))))
+		if err != nil {
+			return "", err
+		}
+		password[i] = chars[n.Int64()]
+	}
+
+	// Shuffle, so the character classes are not always at the start
+	for i := length - 1; i > 0; i-- {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return "", err
+		}
+		j := n.Int64()
+		password[i], password[j] = password[j], password[i]
+	}
+	return string(password), nil
+}
+
 // hashString hashes a string
 func hashString(key string) string {
 	hash := sha3.Sum512([]byte(key))
 	return base64.StdEncoding.EncodeToString(hash[:])
 }
 
+// isHashedString reports whether a string is a hash returned by hashString
+func isHashedString(s string) bool {
+	hash, err := base64.StdEncoding.DecodeString(s)
+	return err == nil && len(hash) == sha3.New512().Size()
+}
+
+// waitForDiskStatus waits for a disk of a Linode instance to reach the desired status. Unlike waiting on events,
+// this can't be confused by other disks of the instance changing at the same time.
+func waitForDiskStatus(client *linodego.Client, linodeID int, diskID int, status string, timeoutSeconds int) error {
+	start := time.Now()
+	for {
+		disk, err := client.GetInstanceDisk(linodeID, diskID)
+		if err != nil {
+			return err
+		}
+		if disk.Status == status {
+			return nil
+		}
+
+		time.Sleep(1 * time.Second)
+		if time.Since(start) > time.Duration(timeoutSeconds)*time.Second {
+			return fmt.Errorf("Disk %d of Linode %d did not become %s in %d seconds", diskID, linodeID, status, timeoutSeconds)
+		}
+	}
+}
+
 // waitForInstanceStatus waits for the Linode instance to reach the desired state
 // before returning. It will timeout with an error after timeoutSeconds.
 func waitForInstanceStatus(client *linodego.Client, linodeID int, status string, timeoutSeconds int) error {
@@ -750,6 +1991,25 @@ func waitForInstanceStatus(client *linodego.Client, linodeID int, status string,
 	}
 }
 
+// waitForDiskDeleted waits until a deleted disk of a Linode instance is gone. Deletions of several disks can not be
+// told apart by their events.
+func waitForDiskDeleted(client *linodego.Client, linodeID int, diskID int, timeoutSeconds int) error {
+	start := time.Now()
+	for {
+		if _, err := client.GetInstanceDisk(linodeID, diskID); err != nil {
+			if lerr, ok := err.(*linodego.Error); ok && lerr.Code == 404 {
+				return nil
+			}
+			return err
+		}
+
+		time.Sleep(1 * time.Second)
+		if time.Since(start) > time.Duration(timeoutSeconds)*time.Second {
+			return fmt.Errorf("Disk %d of Linode %d was not deleted in %d seconds", diskID, linodeID, timeoutSeconds)
+		}
+	}
+}
+
 // waitForInstanceStatus waits for the Linode instance to reach the desired state
 // before returning. It will timeout with an error after timeoutSeconds.
 func waitForEventComplete(client *linodego.Client, linodeID int, action string, timeoutSeconds int) error {
@@ -789,23 +2049,1238 @@ func waitForEventComplete(client *linodego.Client, linodeID int, action string,
 	}
 }
 
-// changeLinodeSize resizes the current linode
-func changeLinodeSize(client *linodego.Client, instance *linodego.Instance, d *schema.ResourceData) error {
-	typeID, ok := d.Get("type").(string)
-	if !ok {
-		return fmt.Errorf("Unexpected value for type %v", d.Get("type"))
-	}
+// suppressLinodeImageLayoutDiff ignores the settings of the disks deployed from image when disks are declared instead
+func suppressLinodeImageLayoutDiff(k, old, new string, d *schema.ResourceData) bool {
+	return d.Get("image").(string) == ""
+}
 
-	targetType, err := getType(client, typeID)
-	if err != nil {
-		return fmt.Errorf("Failed to find the instance type %s", typeID)
+// linodeDiskLabels returns the labels of the disk blocks
+func linodeDiskLabels(disks []interface{}) map[string]bool {
+	labels := make(map[string]bool, len(disks))
+	for _, disk := range disks {
+		labels[disk.(map[string]interface{})["label"].(string)] = true
 	}
+	return labels
+}
 
-	//biggestDiskID, biggestDiskSize, err := getBiggestDisk(client, instance.ID)
-
-	//currentDiskSize, err := getTotalDiskSize(client, instance.ID)
-
-	if ok, err := client.ResizeInstance(instance.ID, typeID); err != nil || !ok {
+// flattenLinodeDisks converts the disks of a Linode instance into disk blocks, in the order of the declared disk
+// blocks. Disks are matched to blocks by label, or by ID when they were renamed outside of Terraform. The image,
+// root_password and ssh_key of a disk can not be read back and are kept from its block. Disks that match no block
+// are appended when all is set, otherwise they are left to other resources such as linode_instance_disk.
+func flattenLinodeDisks(disks []*linodego.InstanceDisk, declared []interface{}, all bool) []map[string]interface{} {
+	matches := make([]*linodego.InstanceDisk, len(declared))
+	used := make(map[int]bool, len(disks))
+
+	for _, byLabel := range []bool{true, false} {
+		for i, block := range declared {
+			block := block.(map[string]interface{})
+			for _, disk := range disks {
+				if matches[i] != nil || used[disk.ID] {
+					continue
+				}
+				if (byLabel && block["label"].(string) == disk.Label) || (!byLabel && block["id"].(int) == disk.ID) {
+					matches[i] = disk
+					used[disk.ID] = true
+				}
+			}
+		}
+	}
+
+	result := make([]map[string]interface{}, 0, len(disks))
+	for i, disk := range matches {
+		if disk == nil {
+			continue
+		}
+		block := declared[i].(map[string]interface{})
+		result = append(result, map[string]interface{}{
+			"id":             disk.ID,
+			"label":          disk.Label,
+			"size":           disk.Size,
+			"filesystem":     disk.Filesystem,
+			"image":          block["image"],
+			"root_password":  block["root_password"],
+			"ssh_key":        block["ssh_key"],
+			"allow_redeploy": block["allow_redeploy"],
+		})
+	}
+
+	for _, disk := range disks {
+		if used[disk.ID] || !all {
+			continue
+		}
+		result = append(result, map[string]interface{}{
+			"id":         disk.ID,
+			"label":      disk.Label,
+			"size":       disk.Size,
+			"filesystem": disk.Filesystem,
+		})
+	}
+
+	return result
+}
+
+// sameLinodeDiskContents reports whether two disk blocks deploy the same filesystem, image and keys. Unlike these, the
+// root_password of a disk can be reset in place.
+func sameLinodeDiskContents(a, b map[string]interface{}) bool {
+	return a["filesystem"] == b["filesystem"] && a["image"] == b["image"] &&
+		strings.Join(expandStringList(a["ssh_key"].([]interface{})), "\n") == strings.Join(expandStringList(b["ssh_key"].([]interface{})), "\n")
+}
+
+// matchLinodeDisks matches new disk blocks to old ones by label, or to a removed label with the same contents when
+// their label changed. It returns the old block matching each new block, or nil.
+func matchLinodeDisks(oldDisks []interface{}, newDisks []interface{}) []map[string]interface{} {
+	newLabels := linodeDiskLabels(newDisks)
+	matches := make([]map[string]interface{}, len(newDisks))
+	used := make([]bool, len(oldDisks))
+
+	for _, byLabel := range []bool{true, false} {
+		for i, block := range newDisks {
+			block := block.(map[string]interface{})
+			for j, oldBlock := range oldDisks {
+				oldBlock := oldBlock.(map[string]interface{})
+				if matches[i] != nil || used[j] {
+					continue
+				}
+				if (byLabel && oldBlock["label"] == block["label"]) ||
+					(!byLabel && !newLabels[oldBlock["label"].(string)] && sameLinodeDiskContents(oldBlock, block)) {
+					matches[i] = oldBlock
+					used[j] = true
+				}
+			}
+		}
+	}
+	return matches
+}
+
+// linodeDiskPasswordChanged reports whether the root_password of a disk deployed from an image changed
+func linodeDiskPasswordChanged(old, new map[string]interface{}) bool {
+	return new["image"].(string) != "" && old["root_password"] != new["root_password"]
+}
+
+// createLinodeDisk creates a disk of a Linode instance from a disk block and waits for it to be ready
+func createLinodeDisk(client *linodego.Client, linodeID int, disk map[string]interface{}) (*linodego.InstanceDisk, error) {
+	diskOpts := linodego.InstanceDiskCreateOptions{
+		Label:          disk["label"].(string),
+		Size:           disk["size"].(int),
+		Filesystem:     disk["filesystem"].(string),
+		Image:          disk["image"].(string),
+		RootPass:       disk["root_password"].(string),
+		AuthorizedKeys: expandStringList(disk["ssh_key"].([]interface{})),
+	}
+
+	instanceDisk, err := client.CreateInstanceDisk(linodeID, diskOpts)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create disk %s of Linode instance %d because %s", diskOpts.Label, linodeID, err)
+	}
+	if _, err = client.WaitForEventFinished(linodeID, linodego.EntityLinode, linodego.ActionDiskCreate, instanceDisk.Created, WaitTimeout); err != nil {
+		return nil, fmt.Errorf("Failed waiting for disk %s of Linode instance %d because %s", diskOpts.Label, linodeID, err)
+	}
+	return instanceDisk, nil
+}
+
+// createLinodeDeclaredDisks creates the declared disks of a new Linode instance, returning the device map that
+// attaches them in order
+func createLinodeDeclaredDisks(client *linodego.Client, linodeID int, disks []interface{}) (*linodego.InstanceConfigDeviceMap, error) {
+	devices := &linodego.InstanceConfigDeviceMap{}
+	diskIDs := make([]int, 0, len(disks))
+	for _, disk := range disks {
+		instanceDisk, err := createLinodeDisk(client, linodeID, disk.(map[string]interface{}))
+		if err != nil {
+			return nil, err
+		}
+		diskIDs = append(diskIDs, instanceDisk.ID)
+	}
+	assignConfigDisks(devices, diskIDs)
+	return devices, nil
+}
+
+// assignConfigDisks attaches disks to the slots of a Linode Config device map in order, replacing the disks it
+// attached before. Slots holding Volumes are kept, and disks beyond the last free slot are not attached.
+func assignConfigDisks(devices *linodego.InstanceConfigDeviceMap, diskIDs []int) {
+	for _, slot := range configDeviceSlots {
+		if device := getConfigDevice(devices, slot); device == nil || device.VolumeID != 0 {
+			continue
+		}
+		if len(diskIDs) == 0 {
+			setConfigDevice(devices, slot, nil)
+			continue
+		}
+		setConfigDevice(devices, slot, &linodego.InstanceConfigDevice{DiskID: diskIDs[0]})
+		diskIDs = diskIDs[1:]
+	}
+	for _, slot := range configDeviceSlots {
+		if len(diskIDs) == 0 {
+			return
+		}
+		if getConfigDevice(devices, slot) == nil {
+			setConfigDevice(devices, slot, &linodego.InstanceConfigDevice{DiskID: diskIDs[0]})
+			diskIDs = diskIDs[1:]
+		}
+	}
+}
+
+// updateLinodeDeclaredDisks reconciles the disks of a Linode instance with its disk blocks. Disks are matched by
+// label, or to a removed disk with the same contents when their label changed. Matched disks are renamed, resized
+// and have their root_password reset in place. Disks whose filesystem, image or ssh_key changed are deleted and
+// created again, losing their data, which customizeDiffLinodeDisks only allows with allow_redeploy. The instance is
+// shut down while disks are deleted, resized or have their password reset, and booted again afterwards.
+func updateLinodeDeclaredDisks(client *linodego.Client, instance *linodego.Instance, d *schema.ResourceData) error {
+	o, n := d.GetChange("disk")
+	oldDisks, newDisks := o.([]interface{}), n.([]interface{})
+	matches := matchLinodeDisks(oldDisks, newDisks)
+
+	type diskChange struct {
+		old map[string]interface{}
+		new map[string]interface{}
+	}
+	var matched []diskChange
+	var created []map[string]interface{}
+	var deleted []map[string]interface{}
+	used := make(map[int]bool, len(oldDisks))
+
+	for i, block := range newDisks {
+		block := block.(map[string]interface{})
+		switch match := matches[i]; {
+		case match == nil:
+			created = append(created, block)
+		case !sameLinodeDiskContents(match, block):
+			used[match["id"].(int)] = true
+			deleted = append(deleted, match)
+			created = append(created, block)
+		default:
+			used[match["id"].(int)] = true
+			matched = append(matched, diskChange{old: match, new: block})
+		}
+	}
+	for _, oldBlock := range oldDisks {
+		if oldBlock := oldBlock.(map[string]interface{}); !used[oldBlock["id"].(int)] {
+			deleted = append(deleted, oldBlock)
+		}
+	}
+
+	shutdown := len(deleted) > 0
+	for _, change := range matched {
+		shutdown = shutdown || change.old["size"] != change.new["size"] || linodeDiskPasswordChanged(change.old, change.new)
+	}
+
+	var err error
+	wasRunning := false
+	if shutdown {
+		if wasRunning, err = shutdownInstanceIfRunning(client, instance.ID); err != nil {
+			return err
+		}
+	}
+
+	for _, disk := range deleted {
+		if err = client.DeleteInstanceDisk(instance.ID, disk["id"].(int)); err != nil {
+			return fmt.Errorf("Failed to delete disk %s of Linode instance %d because %s", disk["label"], instance.ID, err)
+		}
+		if err = waitForDiskDeleted(client, instance.ID, disk["id"].(int), WaitTimeout); err != nil {
+			return fmt.Errorf("Failed waiting for disk %s of Linode instance %d to be deleted because %s", disk["label"], instance.ID, err)
+		}
+	}
+
+	// Shrink disks before growing others into the space they free
+	for _, shrink := range []bool{true, false} {
+		for _, change := range matched {
+			oldSize, newSize := change.old["size"].(int), change.new["size"].(int)
+			if oldSize != newSize && (newSize < oldSize) == shrink {
+				if err = resizeLinodeDisk(client, instance.ID, change.old["id"].(int), newSize); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	for _, change := range matched {
+		if !linodeDiskPasswordChanged(change.old, change.new) {
+			continue
+		}
+		minStart := time.Now()
+		if _, err = client.PasswordResetInstanceDisk(instance.ID, change.old["id"].(int), change.new["root_password"].(string)); err != nil {
+			return fmt.Errorf("Failed to reset the root password of disk %s of Linode instance %d because %s", change.old["label"], instance.ID, err)
+		}
+		if _, err = client.WaitForEventFinished(instance.ID, linodego.EntityLinode, linodego.ActionPasswordReset, minStart, WaitTimeout); err != nil {
+			return fmt.Errorf("Failed waiting for the root password of disk %s of Linode instance %d to be reset because %s", change.old["label"], instance.ID, err)
+		}
+	}
+
+	for _, change := range matched {
+		if change.old["label"] != change.new["label"] {
+			if _, err = client.RenameInstanceDisk(instance.ID, change.old["id"].(int), change.new["label"].(string)); err != nil {
+				return fmt.Errorf("Failed to rename disk %s of Linode instance %d because %s", change.old["label"], instance.ID, err)
+			}
+		}
+	}
+
+	for _, disk := range created {
+		if _, err = createLinodeDisk(client, instance.ID, disk); err != nil {
+			return err
+		}
+	}
+
+	instanceDisks, err := client.ListInstanceDisks(instance.ID, nil)
+	if err != nil {
+		return fmt.Errorf("Failed to get the disks for the Linode instance %d because %s", instance.ID, err)
+	}
+	disks := flattenLinodeDisks(instanceDisks, newDisks, false)
+	d.Set("disk", disks)
+
+	// Declared configs attach disks themselves
+	var config *linodego.InstanceConfig
+	configs, err := client.ListInstanceConfigs(instance.ID, nil)
+	if err != nil {
+		return fmt.Errorf("Failed to fetch the config for Linode instance %d because %s", instance.ID, err)
+	}
+	if current := findLinodeConfig(configs, d.Get("boot_config_label").(string), fmt.Sprintf("linode%d-config", instance.ID)); current != nil && len(d.Get("config").([]interface{})) == 0 {
+		diskIDs := make([]int, 0, len(disks))
+		for _, disk := range disks {
+			diskIDs = append(diskIDs, disk["id"].(int))
+		}
+		configOpts := current.GetUpdateOptions()
+		if configOpts.Devices == nil {
+			configOpts.Devices = &linodego.InstanceConfigDeviceMap{}
+		}
+		assignConfigDisks(configOpts.Devices, diskIDs)
+		if config, err = client.UpdateInstanceConfig(instance.ID, current.ID, configOpts); err != nil {
+			return fmt.Errorf("Failed to update Linode instance %d config because %s", instance.ID, err)
+		}
+	}
+
+	switch {
+	case wasRunning:
+		configID := 0
+		if config != nil {
+			configID = config.ID
+		}
+		return bootInstance(client, instance.ID, configID)
+	case config != nil && len(created) > 0:
+		return rebootInstanceIfRunning(client, instance.ID, config)
+	}
+
+	return nil
+}
+
+// linodeConfigSchema returns the schema of a Linode Config, extended with the given fields
+func linodeConfigSchema(fields map[string]*schema.Schema) map[string]*schema.Schema {
+	result := map[string]*schema.Schema{
+		"label": &schema.Schema{
+			Type:        schema.TypeString,
+			Description: "The label of the config, unique among the configs of the Linode instance.",
+			Required:    true,
+		},
+		"kernel": &schema.Schema{
+			Type:        schema.TypeString,
+			Description: "The kernel to boot with. (examples: linode/latest-64bit, linode/grub2, linode/direct-disk)",
+			Optional:    true,
+			Default:     "linode/latest-64bit",
+		},
+		"root_device": &schema.Schema{
+			Type:        schema.TypeString,
+			Description: "The root device to boot, such as /dev/sda.",
+			Optional:    true,
+			Default:     "/dev/sda",
+		},
+		"run_level": &schema.Schema{
+			Type:         schema.TypeString,
+			Description:  "The run level to boot into (default, single or binbash).",
+			Optional:     true,
+			Default:      "default",
+			ValidateFunc: validateOneOf("default", "single", "binbash"),
+		},
+		"virt_mode": &schema.Schema{
+			Type:         schema.TypeString,
+			Description:  "The virtualization mode (paravirt or fullvirt).",
+			Optional:     true,
+			Default:      "paravirt",
+			ValidateFunc: validateOneOf("paravirt", "fullvirt"),
+		},
+		"memory_limit": &schema.Schema{
+			Type:        schema.TypeInt,
+			Description: "The memory limit of the config in MB, or 0 for the memory of the Linode type.",
+			Optional:    true,
+			Default:     0,
+		},
+		"comments": &schema.Schema{
+			Type:        schema.TypeString,
+			Description: "Optional comments about the config.",
+			Optional:    true,
+		},
+		"helpers": &schema.Schema{
+			Type:        schema.TypeList,
+			Description: "The helpers which adjust the filesystem and network settings of the disks at boot.",
+			Optional:    true,
+			Computed:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"updatedb_disabled": &schema.Schema{
+						Type:        schema.TypeBool,
+						Description: "Disables updatedb cron jobs to avoid disk thrashing.",
+						Optional:    true,
+						Default:     true,
+					},
+					"distro": &schema.Schema{
+						Type:        schema.TypeBool,
+						Description: "Adjusts fstab and inittab or upstart entries for the kernel being booted.",
+						Optional:    true,
+						Default:     true,
+					},
+					"modules_dep": &schema.Schema{
+						Type:        schema.TypeBool,
+						Description: "Creates a modules dependency file for the kernel being booted.",
+						Optional:    true,
+						Default:     true,
+					},
+					"network": &schema.Schema{
+						Type:        schema.TypeBool,
+						Description: "Configures the network of the distribution with the IP addresses of the Linode instance.",
+						Optional:    true,
+						Default:     true,
+					},
+					"devtmpfs_automount": &schema.Schema{
+						Type:        schema.TypeBool,
+						Description: "Mounts devtmpfs at boot.",
+						Optional:    true,
+						Default:     true,
+					},
+				},
+			},
+		},
+		"devices": &schema.Schema{
+			Type:        schema.TypeList,
+			Description: "The disks and Volumes attached to the device slots sda through sdh.",
+			Optional:    true,
+			Computed:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: linodeConfigDevicesSchema(),
+			},
+		},
+	}
+	for k, v := range fields {
+		result[k] = v
+	}
+	return result
+}
+
+// linodeConfigDevicesSchema returns the schema of the device slots of a Linode Config. Volumes in slots that are not
+// declared are kept, so Volumes attached with linode_volume_attachment are not detached.
+func linodeConfigDevicesSchema() map[string]*schema.Schema {
+	slots := make(map[string]*schema.Schema, len(configDeviceSlots))
+	for _, slot := range configDeviceSlots {
+		slots[slot] = &schema.Schema{
+			Type:        schema.TypeList,
+			Description: fmt.Sprintf("The disk or Volume attached as /dev/%s.", slot),
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"disk_label": &schema.Schema{
+						Type:        schema.TypeString,
+						Description: "The label of the disk to attach.",
+						Optional:    true,
+					},
+					"disk_id": &schema.Schema{
+						Type:        schema.TypeInt,
+						Description: "The ID of the disk to attach.",
+						Optional:    true,
+					},
+					"volume_id": &schema.Schema{
+						Type:        schema.TypeInt,
+						Description: "The ID of the Volume to attach.",
+						Optional:    true,
+					},
+				},
+			},
+		}
+	}
+	return slots
+}
+
+// validateLinodeConfigDevices checks that each declared device slot attaches exactly one disk or Volume
+func validateLinodeConfigDevices(devices []interface{}) error {
+	for _, slots := range devices {
+		if slots == nil {
+			continue
+		}
+		for _, slot := range configDeviceSlots {
+			for _, device := range slots.(map[string]interface{})[slot].([]interface{}) {
+				if device == nil {
+					return fmt.Errorf("%s must attach one of disk_label, disk_id or volume_id", slot)
+				}
+				device := device.(map[string]interface{})
+				set := 0
+				for _, key := range []string{"disk_label", "disk_id", "volume_id"} {
+					if v := device[key]; v != "" && v != 0 {
+						set++
+					}
+				}
+				if set != 1 {
+					return fmt.Errorf("%s must attach exactly one of disk_label, disk_id or volume_id", slot)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// findLinodeConfig returns the first config with one of the given labels, trying the labels in order,
+// or the first config when no labels match. Empty labels are skipped.
+func findLinodeConfig(configs []*linodego.InstanceConfig, labels ...string) *linodego.InstanceConfig {
+	for _, label := range labels {
+		for _, config := range configs {
+			if label != "" && config.Label == label {
+				return config
+			}
+		}
+	}
+	if len(configs) > 0 && len(labels) > 1 {
+		return configs[0]
+	}
+	return nil
+}
+
+// expandLinodeConfigDevices builds the device map of a config block. Disks named by label are looked up in diskIDs.
+func expandLinodeConfigDevices(devices []interface{}, diskIDs map[string]int) (*linodego.InstanceConfigDeviceMap, error) {
+	result := &linodego.InstanceConfigDeviceMap{}
+	for _, slots := range devices {
+		if slots == nil {
+			continue
+		}
+		for _, slot := range configDeviceSlots {
+			for _, device := range slots.(map[string]interface{})[slot].([]interface{}) {
+				if device == nil {
+					continue
+				}
+				device := device.(map[string]interface{})
+				configDevice := &linodego.InstanceConfigDevice{
+					DiskID:   device["disk_id"].(int),
+					VolumeID: device["volume_id"].(int),
+				}
+				if label := device["disk_label"].(string); label != "" {
+					diskID, ok := diskIDs[label]
+					if !ok {
+						return nil, fmt.Errorf("Failed to find disk %s for device %s", label, slot)
+					}
+					configDevice.DiskID = diskID
+				}
+				if configDevice.DiskID == 0 && configDevice.VolumeID == 0 {
+					continue
+				}
+				setConfigDevice(result, slot, configDevice)
+			}
+		}
+	}
+	return result, nil
+}
+
+// flattenLinodeConfigDevices converts a device map into a devices block. Disks are named by label unless the
+// declared slot names them by ID.
+func flattenLinodeConfigDevices(devices *linodego.InstanceConfigDeviceMap, disks []*linodego.InstanceDisk, declared []interface{}) []map[string]interface{} {
+	diskLabels := make(map[int]string, len(disks))
+	for _, disk := range disks {
+		diskLabels[disk.ID] = disk.Label
+	}
+
+	var declaredSlots map[string]interface{}
+	if len(declared) > 0 && declared[0] != nil {
+		declaredSlots = declared[0].(map[string]interface{})
+	}
+
+	slots := make(map[string]interface{}, len(configDeviceSlots))
+	for _, slot := range configDeviceSlots {
+		device := getConfigDevice(devices, slot)
+		if device == nil || (device.DiskID == 0 && device.VolumeID == 0) {
+			slots[slot] = []interface{}{}
+			continue
+		}
+
+		byID, isDeclared := false, false
+		if declaredSlots != nil {
+			if declaredDevice, ok := declaredSlots[slot].([]interface{}); ok && len(declaredDevice) > 0 && declaredDevice[0] != nil {
+				byID = declaredDevice[0].(map[string]interface{})["disk_id"].(int) != 0
+				isDeclared = true
+			}
+		}
+
+		// Volumes in undeclared slots are kept by keepLinodeConfigVolumes and left out, so they cause no diff
+		if declaredSlots != nil && !isDeclared && device.DiskID == 0 {
+			slots[slot] = []interface{}{}
+			continue
+		}
+
+		flattened := map[string]interface{}{"volume_id": device.VolumeID}
+		if label, ok := diskLabels[device.DiskID]; ok && !byID {
+			flattened["disk_label"] = label
+		} else {
+			flattened["disk_id"] = device.DiskID
+		}
+		slots[slot] = []interface{}{flattened}
+	}
+	return []map[string]interface{}{slots}
+}
+
+// keepLinodeConfigVolumes copies the Volumes of the current device map into the slots of devices that are empty,
+// so that Volumes attached outside of the config blocks, such as with linode_volume_attachment, stay attached
+func keepLinodeConfigVolumes(devices *linodego.InstanceConfigDeviceMap, current *linodego.InstanceConfigDeviceMap) {
+	for _, slot := range configDeviceSlots {
+		device := getConfigDevice(current, slot)
+		if device == nil || device.VolumeID == 0 {
+			continue
+		}
+		if existing := getConfigDevice(devices, slot); existing == nil || (existing.DiskID == 0 && existing.VolumeID == 0) {
+			setConfigDevice(devices, slot, device)
+		}
+	}
+}
+
+// expandLinodeConfigHelpers builds the helpers of a config block, or nil to use the defaults of the API
+func expandLinodeConfigHelpers(helpers []interface{}) *linodego.InstanceConfigHelpers {
+	if len(helpers) == 0 || helpers[0] == nil {
+		return nil
+	}
+	h := helpers[0].(map[string]interface{})
+	return &linodego.InstanceConfigHelpers{
+		UpdateDBDisabled:  h["updatedb_disabled"].(bool),
+		Distro:            h["distro"].(bool),
+		ModulesDep:        h["modules_dep"].(bool),
+		Network:           h["network"].(bool),
+		DevTmpFsAutomount: h["devtmpfs_automount"].(bool),
+	}
+}
+
+// flattenLinodeConfigHelpers converts the helpers of a config into a helpers block
+func flattenLinodeConfigHelpers(helpers *linodego.InstanceConfigHelpers) []map[string]interface{} {
+	if helpers == nil {
+		return nil
+	}
+	return []map[string]interface{}{{
+		"updatedb_disabled":  helpers.UpdateDBDisabled,
+		"distro":             helpers.Distro,
+		"modules_dep":        helpers.ModulesDep,
+		"network":            helpers.Network,
+		"devtmpfs_automount": helpers.DevTmpFsAutomount,
+	}}
+}
+
+// expandLinodeConfigOptions builds the options of a config from a config block
+func expandLinodeConfigOptions(config map[string]interface{}, diskIDs map[string]int) (linodego.InstanceConfigCreateOptions, error) {
+	devices, err := expandLinodeConfigDevices(config["devices"].([]interface{}), diskIDs)
+	if err != nil {
+		return linodego.InstanceConfigCreateOptions{}, err
+	}
+	return linodego.InstanceConfigCreateOptions{
+		Label:       config["label"].(string),
+		Comments:    config["comments"].(string),
+		Devices:     devices,
+		Helpers:     expandLinodeConfigHelpers(config["helpers"].([]interface{})),
+		MemoryLimit: config["memory_limit"].(int),
+		Kernel:      config["kernel"].(string),
+		RootDevice:  config["root_device"].(string),
+		RunLevel:    config["run_level"].(string),
+		VirtMode:    config["virt_mode"].(string),
+	}, nil
+}
+
+// flattenLinodeConfig converts a config into a config block
+func flattenLinodeConfig(config *linodego.InstanceConfig, disks []*linodego.InstanceDisk, declaredDevices []interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"id":           config.ID,
+		"label":        config.Label,
+		"kernel":       config.Kernel,
+		"root_device":  config.RootDevice,
+		"run_level":    config.RunLevel,
+		"virt_mode":    config.VirtMode,
+		"memory_limit": config.MemoryLimit,
+		"comments":     config.Comments,
+		"helpers":      flattenLinodeConfigHelpers(config.Helpers),
+		"devices":      flattenLinodeConfigDevices(config.Devices, disks, declaredDevices),
+	}
+}
+
+// flattenLinodeConfigs converts the configs of a Linode instance into config blocks, in the order of the declared
+// config blocks. Configs that are not declared, such as recovery profiles created by hand or configs managed with
+// linode_instance_config, are left out.
+func flattenLinodeConfigs(configs []*linodego.InstanceConfig, declared []interface{}, disks []*linodego.InstanceDisk) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(declared))
+	used := make(map[int]bool, len(configs))
+
+	for _, block := range declared {
+		block := block.(map[string]interface{})
+		for _, config := range configs {
+			if !used[config.ID] && config.Label == block["label"].(string) {
+				used[config.ID] = true
+				result = append(result, flattenLinodeConfig(config, disks, block["devices"].([]interface{})))
+				break
+			}
+		}
+	}
+
+	return result
+}
+
+// linodeDiskIDs maps the labels of the disks of a Linode instance to their IDs
+func linodeDiskIDs(client *linodego.Client, linodeID int) (map[string]int, error) {
+	disks, err := client.ListInstanceDisks(linodeID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get the disks for the Linode instance %d because %s", linodeID, err)
+	}
+	diskIDs := make(map[string]int, len(disks))
+	for _, disk := range disks {
+		diskIDs[disk.Label] = disk.ID
+	}
+	return diskIDs, nil
+}
+
+// createLinodeDeclaredConfigs creates the declared configs of a new Linode instance, returning the config to boot.
+// Configs without a devices block get defaultDevices, if given.
+func createLinodeDeclaredConfigs(client *linodego.Client, linodeID int, configs []interface{}, bootLabel string, defaultDevices *linodego.InstanceConfigDeviceMap) (*linodego.InstanceConfig, error) {
+	diskIDs, err := linodeDiskIDs(client, linodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	var bootConfig *linodego.InstanceConfig
+	for _, block := range configs {
+		block := block.(map[string]interface{})
+		configOpts, err := expandLinodeConfigOptions(block, diskIDs)
+		if err != nil {
+			return nil, err
+		}
+		if len(block["devices"].([]interface{})) == 0 && defaultDevices != nil {
+			configOpts.Devices = defaultDevices
+		}
+		config, err := client.CreateInstanceConfig(linodeID, configOpts)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to create config %s of Linode instance %d because %s", configOpts.Label, linodeID, err)
+		}
+		if bootConfig == nil || config.Label == bootLabel {
+			bootConfig = config
+		}
+	}
+	return bootConfig, nil
+}
+
+// removeLinodeDeclaredConfigs deletes the configs of the config blocks removed from a Linode instance. When no config
+// is left, the config created from kernel is created again with the disks of the instance.
+func removeLinodeDeclaredConfigs(client *linodego.Client, instance *linodego.Instance, d *schema.ResourceData) error {
+	configs, err := client.ListInstanceConfigs(instance.ID, nil)
+	if err != nil {
+		return fmt.Errorf("Failed to fetch the configs for Linode instance %d because %s", instance.ID, err)
+	}
+
+	// Only configs of the removed config blocks are deleted, others may belong to linode_instance_config
+	removed := make(map[string]bool)
+	old, _ := d.GetChange("config")
+	for _, block := range old.([]interface{}) {
+		removed[block.(map[string]interface{})["label"].(string)] = true
+	}
+	remaining := 0
+	for _, config := range configs {
+		if !removed[config.Label] {
+			remaining++
+			continue
+		}
+		if err = client.DeleteInstanceConfig(instance.ID, config.ID); err != nil {
+			return fmt.Errorf("Failed to delete config %s of Linode instance %d because %s", config.Label, instance.ID, err)
+		}
+	}
+	if remaining > 0 {
+		return nil
+	}
+
+	var diskIDs []int
+	if declared := d.Get("disk").([]interface{}); len(declared) > 0 {
+		for _, disk := range declared {
+			diskIDs = append(diskIDs, disk.(map[string]interface{})["id"].(int))
+		}
+	} else {
+		instanceDisks, err := client.ListInstanceDisks(instance.ID, nil)
+		if err != nil {
+			return fmt.Errorf("Failed to get the disks for the Linode instance %d because %s", instance.ID, err)
+		}
+		rootDisk, swapDisk := findLinodeRootAndSwapDisks(instanceDisks)
+		for _, disk := range []*linodego.InstanceDisk{rootDisk, swapDisk} {
+			if disk != nil {
+				diskIDs = append(diskIDs, disk.ID)
+			}
+		}
+	}
+	devices := &linodego.InstanceConfigDeviceMap{}
+	assignConfigDisks(devices, diskIDs)
+
+	configOpts := linodego.InstanceConfigCreateOptions{
+		Label:  fmt.Sprintf("linode%d-config", instance.ID),
+		Kernel: d.Get("kernel").(string),
+		Helpers: &linodego.InstanceConfigHelpers{
+			Distro:  d.Get("helper_distro").(bool),
+			Network: d.Get("helper_network").(bool),
+		},
+		Devices: devices,
+	}
+	if _, err = client.CreateInstanceConfig(instance.ID, configOpts); err != nil {
+		return fmt.Errorf("Failed to create Linode instance %d config because %s", instance.ID, err)
+	}
+	return nil
+}
+
+// updateLinodeDeclaredConfigs reconciles the configs of a Linode instance with its config blocks. Configs are
+// matched by label; configs removed from the blocks are deleted. It returns the config to boot and whether it
+// changed, so the instance must be rebooted into it.
+func updateLinodeDeclaredConfigs(client *linodego.Client, linodeID int, d *schema.ResourceData) (*linodego.InstanceConfig, bool, error) {
+	configs, err := client.ListInstanceConfigs(linodeID, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("Failed to fetch the configs for Linode instance %d because %s", linodeID, err)
+	}
+	// Disks named by label may have been replaced, so their devices are reconciled as well
+	if !d.HasChange("config") && !d.HasChange("boot_config_label") && !d.HasChange("disk") {
+		return findLinodeConfig(configs, d.Get("boot_config_label").(string), d.Get("config.0.label").(string)), false, nil
+	}
+
+	diskIDs, err := linodeDiskIDs(client, linodeID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	bootLabel := d.Get("boot_config_label").(string)
+	if d.HasChange("boot_config_label") && bootLabel == "" {
+		// A removed boot_config_label plans no value, boot the first declared config instead
+		bootLabel = d.Get("config.0.label").(string)
+	}
+	changed := d.HasChange("boot_config_label")
+
+	var bootConfig *linodego.InstanceConfig
+	declared := make(map[string]bool)
+	for _, block := range d.Get("config").([]interface{}) {
+		configOpts, err := expandLinodeConfigOptions(block.(map[string]interface{}), diskIDs)
+		if err != nil {
+			return nil, false, err
+		}
+		declared[configOpts.Label] = true
+
+		config := findLinodeConfig(configs, configOpts.Label)
+		switch {
+		case config == nil:
+			if config, err = client.CreateInstanceConfig(linodeID, configOpts); err != nil {
+				return nil, false, fmt.Errorf("Failed to create config %s of Linode instance %d because %s", configOpts.Label, linodeID, err)
+			}
+			changed = changed || config.Label == bootLabel
+		default:
+			keepLinodeConfigVolumes(configOpts.Devices, config.Devices)
+			if configOpts.Helpers == nil {
+				configOpts.Helpers = config.Helpers
+			}
+			if reflect.DeepEqual(config.GetUpdateOptions(), linodego.InstanceConfigUpdateOptions(configOpts)) {
+				break
+			}
+			if config, err = client.UpdateInstanceConfig(linodeID, config.ID, linodego.InstanceConfigUpdateOptions(configOpts)); err != nil {
+				return nil, false, fmt.Errorf("Failed to update config %s of Linode instance %d because %s", configOpts.Label, linodeID, err)
+			}
+			changed = changed || config.Label == bootLabel
+		}
+		if bootConfig == nil || config.Label == bootLabel {
+			bootConfig = config
+		}
+	}
+
+	// Only configs removed from the config blocks are deleted, others may belong to linode_instance_config
+	removed := make(map[string]bool)
+	old, _ := d.GetChange("config")
+	for _, block := range old.([]interface{}) {
+		if label := block.(map[string]interface{})["label"].(string); !declared[label] {
+			removed[label] = true
+		}
+	}
+	for _, config := range configs {
+		if !removed[config.Label] {
+			continue
+		}
+		if err = client.DeleteInstanceConfig(linodeID, config.ID); err != nil {
+			return nil, false, fmt.Errorf("Failed to delete config %s of Linode instance %d because %s", config.Label, linodeID, err)
+		}
+	}
+
+	d.Set("boot_config_label", bootConfig.Label)
+	return bootConfig, changed, nil
+}
+
+// rebuildLinodeInstance redeploys the image of a Linode instance, which replaces all of its disks and configs while
+// keeping its ID and IP addresses. The disks and configs are then laid out as they are on a new instance, and the
+// instance is booted.
+func rebuildLinodeInstance(client *linodego.Client, instance *linodego.Instance, d *schema.ResourceData) error {
+	rebuildOpts := linodego.RebuildInstanceOptions{
+		Image:          d.Get("image").(string),
+		RootPass:       d.Get("root_password").(string),
+		AuthorizedKeys: expandStringList(d.Get("ssh_key").([]interface{})),
+		Booted:         false,
+	}
+
+	if stackscriptID, ok := d.GetOk("stackscript_id"); ok {
+		rebuildOpts.StackscriptID = stackscriptID.(int)
+		rebuildOpts.StackscriptData = make(map[string]string)
+		for name, value := range d.Get("stackscript_data").(map[string]interface{}) {
+			rebuildOpts.StackscriptData[name] = value.(string)
+		}
+	}
+
+	if _, err := client.RebuildInstance(instance.ID, &rebuildOpts); err != nil {
+		return fmt.Errorf("Failed to rebuild Linode instance %d because %s", instance.ID, err)
+	}
+
+	event, err := client.WaitForEventFinished(instance.ID, linodego.EntityLinode, linodego.ActionLinodeRebuild, *instance.Updated, WaitTimeout)
+	if err != nil {
+		return fmt.Errorf("Failed while waiting for Linode instance %d to finish rebuilding because %s", instance.ID, err)
+	}
+
+	if instance, err = client.GetInstance(instance.ID); err != nil {
+		return fmt.Errorf("Failed to fetch data about the rebuilt Linode instance %d because %s", instance.ID, err)
+	}
+
+	disks, err := client.ListInstanceDisks(instance.ID, nil)
+	if err != nil {
+		return fmt.Errorf("Failed to get the disks for the Linode instance %d because %s", instance.ID, err)
+	}
+
+	rootDisk, swapDisk := findLinodeRootAndSwapDisks(disks)
+	if rootDisk == nil {
+		return fmt.Errorf("Failed to find the root disk of the rebuilt Linode instance %d", instance.ID)
+	}
+
+	swapSize := d.Get("swap_size").(int)
+	rootSize := instance.Specs.Disk - swapSize
+
+	// Free space by shrinking or removing swap before the root disk grows into it
+	if swapDisk != nil && swapSize == 0 {
+		if err = client.DeleteInstanceDisk(instance.ID, swapDisk.ID); err != nil {
+			return fmt.Errorf("Failed to delete Linode instance %d swap disk because %s", instance.ID, err)
+		}
+		if _, err = client.WaitForEventFinished(instance.ID, linodego.EntityLinode, linodego.ActionDiskDelete, *event.Created, WaitTimeout); err != nil {
+			return fmt.Errorf("Failed waiting for Linode instance %d swap disk to be deleted because %s", instance.ID, err)
+		}
+		swapDisk = nil
+	} else if swapDisk != nil && swapDisk.Size > swapSize {
+		if err = resizeLinodeDisk(client, instance.ID, swapDisk.ID, swapSize); err != nil {
+			return err
+		}
+	}
+
+	if rootDisk.Size != rootSize {
+		if err = resizeLinodeDisk(client, instance.ID, rootDisk.ID, rootSize); err != nil {
+			return err
+		}
+	}
+
+	if swapDisk != nil && swapDisk.Size < swapSize {
+		if err = resizeLinodeDisk(client, instance.ID, swapDisk.ID, swapSize); err != nil {
+			return err
+		}
+	} else if swapDisk == nil && swapSize > 0 {
+		swapOpts := linodego.InstanceDiskCreateOptions{
+			Label:      "linode" + strconv.Itoa(instance.ID) + "-swap",
+			Filesystem: "swap",
+			Size:       swapSize,
+		}
+		if swapDisk, err = client.CreateInstanceDisk(instance.ID, swapOpts); err != nil {
+			return fmt.Errorf("Failed to create Linode instance %d swap disk because %s", instance.ID, err)
+		}
+		if _, err = client.WaitForEventFinished(instance.ID, linodego.EntityLinode, linodego.ActionDiskCreate, swapDisk.Created, WaitTimeout); err != nil {
+			return fmt.Errorf("Failed waiting for Linode instance %d swap disk because %s", instance.ID, err)
+		}
+	}
+
+	if _, err = client.RenameInstanceDisk(instance.ID, rootDisk.ID, "linode"+strconv.Itoa(instance.ID)+"-root"); err != nil {
+		return fmt.Errorf("Failed to rename Linode instance %d root disk because %s", instance.ID, err)
+	}
+	if swapDisk != nil && swapDisk.Label != "linode"+strconv.Itoa(instance.ID)+"-swap" {
+		if _, err = client.RenameInstanceDisk(instance.ID, swapDisk.ID, "linode"+strconv.Itoa(instance.ID)+"-swap"); err != nil {
+			return fmt.Errorf("Failed to rename Linode instance %d swap disk because %s", instance.ID, err)
+		}
+	}
+
+	configDevices := &linodego.InstanceConfigDeviceMap{
+		SDA: &linodego.InstanceConfigDevice{DiskID: rootDisk.ID},
+	}
+	if swapDisk != nil {
+		configDevices.SDB = &linodego.InstanceConfigDevice{DiskID: swapDisk.ID}
+	}
+
+	configs, err := client.ListInstanceConfigs(instance.ID, nil)
+	if err != nil {
+		return fmt.Errorf("Failed to fetch the config for Linode instance %d because %s", instance.ID, err)
+	}
+	for _, config := range configs {
+		if err = client.DeleteInstanceConfig(instance.ID, config.ID); err != nil {
+			return fmt.Errorf("Failed to delete Linode instance %d config %d because %s", instance.ID, config.ID, err)
+		}
+	}
+
+	var config *linodego.InstanceConfig
+	if configBlocks := d.Get("config").([]interface{}); len(configBlocks) > 0 {
+		if config, err = createLinodeDeclaredConfigs(client, instance.ID, configBlocks, d.Get("boot_config_label").(string), configDevices); err != nil {
+			return err
+		}
+		d.Set("boot_config_label", config.Label)
+	} else {
+		configOpts := linodego.InstanceConfigCreateOptions{
+			Label:  fmt.Sprintf("linode%d-config", instance.ID),
+			Kernel: d.Get("kernel").(string),
+			Helpers: &linodego.InstanceConfigHelpers{
+				Distro:  d.Get("helper_distro").(bool),
+				Network: d.Get("helper_network").(bool),
+			},
+			Devices: configDevices,
+		}
+		if config, err = client.CreateInstanceConfig(instance.ID, configOpts); err != nil {
+			return fmt.Errorf("Failed to create Linode instance %d config because %s", instance.ID, err)
+		}
+	}
+
+	if !d.Get("booted").(bool) {
+		return nil
+	}
+	return bootInstance(client, instance.ID, config.ID)
+}
+
+// findLinodeRootAndSwapDisks returns the root and swap disks of a Linode instance deployed from an image, preferring
+// the disks labeled as Terraform creates them and falling back to the first other disk and the first swap disk
+func findLinodeRootAndSwapDisks(disks []*linodego.InstanceDisk) (rootDisk *linodego.InstanceDisk, swapDisk *linodego.InstanceDisk) {
+	for _, disk := range disks {
+		isSwap := disk.Filesystem == "swap"
+		switch {
+		case isSwap && (swapDisk == nil || strings.HasSuffix(disk.Label, "-swap") && !strings.HasSuffix(swapDisk.Label, "-swap")):
+			swapDisk = disk
+		case !isSwap && (rootDisk == nil || strings.HasSuffix(disk.Label, "-root") && !strings.HasSuffix(rootDisk.Label, "-root")):
+			rootDisk = disk
+		}
+	}
+	return rootDisk, swapDisk
+}
+
+// updateLinodeSwapSize resizes, creates or deletes the swap disk of a Linode instance deployed from an image. The root
+// disk is shrunk first when there is not enough free space for the new swap size. The instance is shut down while
+// the disks change and booted again afterwards.
+func updateLinodeSwapSize(client *linodego.Client, instance *linodego.Instance, d *schema.ResourceData) error {
+	// The plan storage may have changed with the type
+	linodeID := instance.ID
+	instance, err := client.GetInstance(linodeID)
+	if err != nil {
+		return fmt.Errorf("Failed to fetch data about Linode instance %d because %s", linodeID, err)
+	}
+	disks, err := client.ListInstanceDisks(instance.ID, nil)
+	if err != nil {
+		return fmt.Errorf("Failed to get the disks for the Linode instance %d because %s", instance.ID, err)
+	}
+	rootDisk, swapDisk := findLinodeRootAndSwapDisks(disks)
+
+	swapSize, currentSwapSize, used := d.Get("swap_size").(int), 0, 0
+	if swapDisk != nil {
+		currentSwapSize = swapDisk.Size
+	}
+	for _, disk := range disks {
+		used += disk.Size
+	}
+	shrinkRoot := swapSize - currentSwapSize - (instance.Specs.Disk - used)
+	if shrinkRoot > 0 && rootDisk == nil {
+		return fmt.Errorf("Linode instance %d has no root disk to make room for %d MB of swap", instance.ID, swapSize)
+	}
+
+	wasRunning, err := shutdownInstanceIfRunning(client, instance.ID)
+	if err != nil {
+		return err
+	}
+
+	configs, err := client.ListInstanceConfigs(instance.ID, nil)
+	if err != nil {
+		return fmt.Errorf("Failed to fetch the config for Linode instance %d because %s", instance.ID, err)
+	}
+	config := findLinodeConfig(configs, d.Get("boot_config_label").(string), fmt.Sprintf("linode%d-config", instance.ID))
+
+	switch {
+	case swapSize == 0 && swapDisk != nil:
+		if err = detachLinodeDisk(client, instance.ID, configs, swapDisk.ID); err != nil {
+			return err
+		}
+		if err = client.DeleteInstanceDisk(instance.ID, swapDisk.ID); err != nil {
+			return fmt.Errorf("Failed to delete Linode instance %d swap disk because %s", instance.ID, err)
+		}
+		if err = waitForDiskDeleted(client, instance.ID, swapDisk.ID, WaitTimeout); err != nil {
+			return fmt.Errorf("Failed waiting for Linode instance %d swap disk to be deleted because %s", instance.ID, err)
+		}
+
+	case swapSize > 0:
+		if shrinkRoot > 0 {
+			if err = resizeLinodeDisk(client, instance.ID, rootDisk.ID, rootDisk.Size-shrinkRoot); err != nil {
+				return err
+			}
+		}
+
+		if swapDisk != nil {
+			if err = resizeLinodeDisk(client, instance.ID, swapDisk.ID, swapSize); err != nil {
+				return err
+			}
+			break
+		}
+
+		if swapDisk, err = createLinodeDisk(client, instance.ID, map[string]interface{}{
+			"label":         "linode" + strconv.Itoa(instance.ID) + "-swap",
+			"size":          swapSize,
+			"filesystem":    "swap",
+			"image":         "",
+			"root_password": "",
+			"ssh_key":       []interface{}{},
+		}); err != nil {
+			return err
+		}
+		if config != nil {
+			if config, err = attachLinodeDisk(client, instance.ID, config, swapDisk.ID, "sdb"); err != nil {
+				return err
+			}
+		}
+	}
+
+	if !wasRunning {
+		return nil
+	}
+	configID := 0
+	if config != nil {
+		configID = config.ID
+	}
+	return bootInstance(client, instance.ID, configID)
+}
+
+// attachLinodeDisk attaches a disk to the given slot of a Linode Config, or to its first free slot when the given
+// slot is in use
+func attachLinodeDisk(client *linodego.Client, linodeID int, config *linodego.InstanceConfig, diskID int, slot string) (*linodego.InstanceConfig, error) {
+	configOpts := config.GetUpdateOptions()
+	if configOpts.Devices == nil {
+		configOpts.Devices = &linodego.InstanceConfigDeviceMap{}
+	}
+
+	free := ""
+	for _, candidate := range append([]string{slot}, configDeviceSlots...) {
+		if device := getConfigDevice(configOpts.Devices, candidate); device == nil || (device.DiskID == 0 && device.VolumeID == 0) {
+			free = candidate
+			break
+		}
+	}
+	if free == "" {
+		return nil, fmt.Errorf("Config %d of Linode instance %d has no free device slot for disk %d", config.ID, linodeID, diskID)
+	}
+	setConfigDevice(configOpts.Devices, free, &linodego.InstanceConfigDevice{DiskID: diskID})
+
+	updated, err := client.UpdateInstanceConfig(linodeID, config.ID, configOpts)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to update config %d of Linode instance %d because %s", config.ID, linodeID, err)
+	}
+	return updated, nil
+}
+
+// detachLinodeDisk removes a disk from the device maps of the configs of a Linode instance
+func detachLinodeDisk(client *linodego.Client, linodeID int, configs []*linodego.InstanceConfig, diskID int) error {
+	for _, config := range configs {
+		configOpts := config.GetUpdateOptions()
+		detached := false
+		for _, slot := range configDeviceSlots {
+			if device := getConfigDevice(configOpts.Devices, slot); device != nil && device.DiskID == diskID {
+				setConfigDevice(configOpts.Devices, slot, nil)
+				detached = true
+			}
+		}
+		if !detached {
+			continue
+		}
+		if _, err := client.UpdateInstanceConfig(linodeID, config.ID, configOpts); err != nil {
+			return fmt.Errorf("Failed to update config %d of Linode instance %d because %s", config.ID, linodeID, err)
+		}
+	}
+	return nil
+}
+
+// resizeLinodeDisk resizes a disk of a Linode instance and waits for the resize to finish
+func resizeLinodeDisk(client *linodego.Client, linodeID int, diskID int, size int) error {
+	disk, err := client.ResizeInstanceDisk(linodeID, diskID, size)
+	if err != nil {
+		return fmt.Errorf("Failed to resize Disk %d for Linode %d because %s", diskID, linodeID, err)
+	}
+	if _, err = client.WaitForEventFinished(linodeID, linodego.EntityLinode, linodego.ActionDiskResize, disk.Updated, WaitTimeout); err != nil {
+		return fmt.Errorf("Failed to wait for resize of Disk %d for Linode %d because %s", diskID, linodeID, err)
+	}
+	return nil
+}
+
+// migrateLinodeInstance migrates a Linode instance and its disks to another region, waiting for the migration to
+// finish and the instance to return to the state it was in
+func migrateLinodeInstance(client *linodego.Client, instance *linodego.Instance, region string) error {
+	if _, err := client.MigrateInstance(instance.ID, linodego.InstanceMigrateOptions{Region: region}); err != nil {
+		return fmt.Errorf("Failed to migrate Linode instance %d to %s because %s", instance.ID, region, err)
+	}
+
+	// Migrations copy the disks like resizes, so allow as long
+	waitSeconds := ((instance.Specs.Disk / 1024) * 180)
+	if _, err := client.WaitForEventFinished(instance.ID, linodego.EntityLinode, linodego.ActionLinodeMigrate, *instance.Updated, waitSeconds); err != nil {
+		return fmt.Errorf("Failed while waiting for Linode instance %d to finish migrating because %s", instance.ID, err)
+	}
+
+	status := InstanceOffline
+	if instance.Status == InstanceRunning {
+		status = InstanceRunning
+	}
+	if err := waitForInstanceStatus(client, instance.ID, status, WaitTimeout); err != nil {
+		return fmt.Errorf("Timed-out waiting for Linode instance %d to be %s after migrating because %s", instance.ID, status, err)
+	}
+	return nil
+}
+
+// changeLinodeSize resizes the current linode. When the disks of a Linode deployed from an image exceed the storage
+// of the new type, the largest disk is shrunk to fit first. When disk_expansion is set, the largest disk is expanded
+// to fill the storage of the new type afterwards. The Linode is shut down while its disks are resized and booted
+// again afterwards.
+func changeLinodeSize(client *linodego.Client, instance *linodego.Instance, d *schema.ResourceData) error {
+	typeID, ok := d.Get("type").(string)
+	if !ok {
+		return fmt.Errorf("Unexpected value for type %v", d.Get("type"))
+	}
+
+	targetType, err := getType(client, typeID)
+	if err != nil {
+		return fmt.Errorf("Failed to find the instance type %s", typeID)
+	}
+
+	// Declared disks are resized by updateLinodeDeclaredDisks instead
+	manageDisks := d.Get("image").(string) != ""
+	diskExpansion := manageDisks && d.Get("disk_expansion").(bool)
+
+	currentDiskSize, err := getTotalDiskSize(client, instance.ID)
+	if err != nil {
+		return fmt.Errorf("Failed to get the disks for the Linode instance %d because %s", instance.ID, err)
+	}
+	biggestDiskID, biggestDiskSize, err := getBiggestDisk(client, instance.ID)
+	if err != nil {
+		return fmt.Errorf("Failed to get the disks for the Linode instance %d because %s", instance.ID, err)
+	}
+
+	shrink := currentDiskSize - targetType.Disk
+	if shrink > 0 && !manageDisks {
+		return fmt.Errorf("The disks of Linode instance %d total %d MB, more than the %d MB of storage of Linode type %s", instance.ID, currentDiskSize, targetType.Disk, targetType.ID)
+	}
+	expand := diskExpansion && shrink < 0
+
+	wasRunning := false
+	if shrink > 0 || expand {
+		if wasRunning, err = shutdownInstanceIfRunning(client, instance.ID); err != nil {
+			return err
+		}
+	}
+
+	if shrink > 0 {
+		biggestDiskSize -= shrink
+		if err = resizeLinodeDisk(client, instance.ID, biggestDiskID, biggestDiskSize); err != nil {
+			return err
+		}
+	}
+
+	if _, err := client.ResizeInstance(instance.ID, typeID); err != nil {
 		return fmt.Errorf("Failed resizing instance %d because %s", instance.ID, err)
 	}
 
@@ -814,28 +3289,24 @@ func changeLinodeSize(client *linodego.Client, instance *linodego.Instance, d *s
 	// and the filesystem expansion
 	waitSeconds := ((instance.Specs.Disk / 1024) * 180)
 
-	event, err := client.WaitForEventFinished(instance.ID, linodego.EntityLinode, linodego.ActionLinodeResize, *instance.Created, waitSeconds)
-	if err != nil {
+	if _, err = client.WaitForEventFinished(instance.ID, linodego.EntityLinode, linodego.ActionLinodeResize, *instance.Updated, waitSeconds); err != nil {
 		return fmt.Errorf("Failed while waiting for instance %d to finish resizing because %s", instance.ID, err)
 	}
 
-	if d.Get("disk_expansion").(bool) && instance.Specs.Disk > targetType.Disk {
-		// Determine the biggestDisk ID and Size
-		biggestDiskID, biggestDiskSize, err := getBiggestDisk(client, instance.ID)
-		if err != nil {
+	if expand {
+		// A Linode that was running when the resize started is booted again by the API
+		if _, err = shutdownInstanceIfRunning(client, instance.ID); err != nil {
 			return err
 		}
 		// Calculate new size, with other disks taken into consideration
-		expandedDiskSize := biggestDiskSize + targetType.Disk - instance.Specs.Disk
-
-		// Resize the Disk
-		client.ResizeInstanceDisk(instance.ID, biggestDiskID, expandedDiskSize)
+		if err = resizeLinodeDisk(client, instance.ID, biggestDiskID, biggestDiskSize-shrink); err != nil {
+			return err
+		}
+	}
 
-		// Wait for the Disk Resize Operation to Complete
-		// waitForEventComplete(client, instance.ID, "linode_resize", waitMinutes)
-		event, err = client.WaitForEventFinished(instance.ID, linodego.EntityLinode, linodego.ActionDiskResize, *event.Created, waitSeconds)
-		if err != nil {
-			return fmt.Errorf("Failed to wait for resize of Disk %d for Linode %d because %s", biggestDiskID, instance.ID, err)
+	if wasRunning {
+		if err = bootInstance(client, instance.ID, 0); err != nil {
+			return err
 		}
 	}
 
@@ -845,6 +3316,160 @@ func changeLinodeSize(client *linodego.Client, instance *linodego.Instance, d *s
 	return nil
 }
 
+// configDeviceSlots lists the device slots of a Linode Config in boot order
+var configDeviceSlots = []string{"sda", "sdb", "sdc", "sdd", "sde", "sdf", "sdg", "sdh"}
+
+// getConfigDevice returns the device assigned to the named slot of a Linode Config device map
+func getConfigDevice(devices *linodego.InstanceConfigDeviceMap, slot string) *linodego.InstanceConfigDevice {
+	if devices == nil {
+		return nil
+	}
+	switch slot {
+	case "sda":
+		return devices.SDA
+	case "sdb":
+		return devices.SDB
+	case "sdc":
+		return devices.SDC
+	case "sdd":
+		return devices.SDD
+	case "sde":
+		return devices.SDE
+	case "sdf":
+		return devices.SDF
+	case "sdg":
+		return devices.SDG
+	case "sdh":
+		return devices.SDH
+	}
+	return nil
+}
+
+// setConfigDevice assigns a device to the named slot of a Linode Config device map
+func setConfigDevice(devices *linodego.InstanceConfigDeviceMap, slot string, device *linodego.InstanceConfigDevice) error {
+	switch slot {
+	case "sda":
+		devices.SDA = device
+	case "sdb":
+		devices.SDB = device
+	case "sdc":
+		devices.SDC = device
+	case "sdd":
+		devices.SDD = device
+	case "sde":
+		devices.SDE = device
+	case "sdf":
+		devices.SDF = device
+	case "sdg":
+		devices.SDG = device
+	case "sdh":
+		devices.SDH = device
+	default:
+		return fmt.Errorf("Unknown Linode Config device slot %s", slot)
+	}
+	return nil
+}
+
+// findConfigVolumeSlot returns the slot a Volume occupies in a Linode Config device map, or "" if it is not assigned
+func findConfigVolumeSlot(devices *linodego.InstanceConfigDeviceMap, volumeID int) string {
+	for _, slot := range configDeviceSlots {
+		if device := getConfigDevice(devices, slot); device != nil && device.VolumeID == volumeID {
+			return slot
+		}
+	}
+	return ""
+}
+
+// rebootInstanceIfRunning reboots a running Linode instance into the given config so that
+// config changes take effect. Instances that are not running are left alone.
+func rebootInstanceIfRunning(client *linodego.Client, linodeID int, config *linodego.InstanceConfig) error {
+	instance, err := client.GetInstance(linodeID)
+	if err != nil {
+		return fmt.Errorf("Failed to fetch data about Linode instance %d because %s", linodeID, err)
+	}
+	if instance.Status != InstanceRunning {
+		return nil
+	}
+
+	minStart := time.Now()
+	if _, err = client.RebootInstance(linodeID, config.ID); err != nil {
+		return fmt.Errorf("Failed to reboot Linode instance %d because %s", linodeID, err)
+	}
+	if _, err = client.WaitForEventFinished(linodeID, linodego.EntityLinode, linodego.ActionLinodeReboot, minStart, WaitTimeout); err != nil {
+		return fmt.Errorf("Failed while waiting for Linode instance %d to finish rebooting because %s", linodeID, err)
+	}
+	return nil
+}
+
+// isLinodeBooted reports whether a Linode instance is running or on its way to running
+func isLinodeBooted(instance *linodego.Instance) bool {
+	return instance.Status != InstanceOffline && instance.Status != InstanceShuttingDown
+}
+
+// shutdownInstanceIfRunning shuts down a running Linode instance and waits for it to go offline, returning
+// whether it was running so it can be booted again afterwards
+func shutdownInstanceIfRunning(client *linodego.Client, linodeID int) (bool, error) {
+	instance, err := client.GetInstance(linodeID)
+	if err != nil {
+		return false, fmt.Errorf("Failed to fetch data about Linode instance %d because %s", linodeID, err)
+	}
+	if instance.Status != InstanceRunning {
+		return false, nil
+	}
+
+	if _, err = client.ShutdownInstance(linodeID); err != nil {
+		return false, fmt.Errorf("Failed to shutdown Linode instance %d because %s", linodeID, err)
+	}
+	if err = waitForInstanceStatus(client, linodeID, InstanceOffline, WaitTimeout); err != nil {
+		return false, fmt.Errorf("Timed-out waiting for Linode instance %d to shutdown because %s", linodeID, err)
+	}
+	return true, nil
+}
+
+// bootInstance boots a Linode instance into the given config, or the config it last booted with when configID
+// is 0, and waits for it to be running
+func bootInstance(client *linodego.Client, linodeID int, configID int) error {
+	if booted, err := client.BootInstance(linodeID, configID); !booted {
+		return fmt.Errorf("Failed to boot Linode instance %d because %s", linodeID, err)
+	}
+	if err := waitForInstanceStatus(client, linodeID, InstanceRunning, WaitTimeout); err != nil {
+		return fmt.Errorf("Timed-out waiting for Linode instance %d to boot because %s", linodeID, err)
+	}
+	return nil
+}
+
+// parseCompositeID splits a comma separated ID into the expected number of integer IDs
+func parseCompositeID(id string, count int) ([]int, error) {
+	parts := strings.Split(id, ",")
+	if len(parts) != count {
+		return nil, fmt.Errorf("expected %d comma separated IDs, got %d", count, len(parts))
+	}
+
+	ids := make([]int, count)
+	for i, part := range parts {
+		v, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %q as int: %s", part, err)
+		}
+		ids[i] = v
+	}
+	return ids, nil
+}
+
+// validateOneOf returns a ValidateFunc that checks a string is one of the allowed values
+func validateOneOf(allowed ...string) schema.SchemaValidateFunc {
+	return func(v interface{}, k string) (ws []string, es []error) {
+		value := v.(string)
+		for _, a := range allowed {
+			if value == a {
+				return
+			}
+		}
+		es = append(es, fmt.Errorf("%q must be one of %s, got %s", k, strings.Join(allowed, ", "), value))
+		return
+	}
+}
+
 // Converts a bool to a string
 func boolToString(val bool) string {
 	if val {
@@ -0,0 +1,285 @@
+package linode
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/chiefy/linodego"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// domainSecondsAllowed holds the values the API accepts for a Domain's TTL, refresh, retry and expire.
+// Any other value is rounded server-side, so values are snapped to this set while planning.
+var domainSecondsAllowed = []int{0, 300, 3600, 7200, 14400, 28800, 57600, 86400, 172800, 345600, 604800, 1209600, 2419200}
+
+func resourceLinodeDomain() *schema.Resource {
+	return &schema.Resource{
+		Create:        resourceLinodeDomainCreate,
+		Read:          resourceLinodeDomainRead,
+		Update:        resourceLinodeDomainUpdate,
+		Delete:        resourceLinodeDomainDelete,
+		CustomizeDiff: resourceLinodeDomainCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"domain": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "The domain this Domain represents. These must be unique in our system; you cannot have two Domains representing the same domain.",
+				Required:    true,
+			},
+			"type": &schema.Schema{
+				Type:         schema.TypeString,
+				Description:  "If this Domain represents the authoritative source of information for the domain it describes, or if it is a read-only copy of a master (also called a slave).",
+				Required:     true,
+				ValidateFunc: validateOneOf("master", "slave"),
+			},
+			"group": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "The group this Domain belongs to. This is for display purposes only.",
+				Optional:    true,
+			},
+			"status": &schema.Schema{
+				Type:         schema.TypeString,
+				Description:  "Used to control whether this Domain is currently being rendered. (disabled, active, edit_mode)",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateOneOf("disabled", "active", "edit_mode"),
+			},
+			"description": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "A description for this Domain. This is for display purposes only.",
+				Optional:    true,
+			},
+			"soa_email": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "Start of Authority email address. This is required for master Domains.",
+				Optional:    true,
+			},
+			"master_ips": &schema.Schema{
+				Type:        schema.TypeSet,
+				Description: "The IP addresses representing the master DNS for this Domain. This is required for slave Domains.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Optional:    true,
+			},
+			"axfr_ips": &schema.Schema{
+				Type:        schema.TypeSet,
+				Description: "The list of IPs that may perform a zone transfer for this Domain. This is potentially dangerous, and should be set to an empty list unless you intend to use it.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Optional:    true,
+			},
+			"ttl_sec": &schema.Schema{
+				Type:         schema.TypeInt,
+				Description:  "The amount of time in seconds that this Domain's records may be cached by resolvers or other domain servers. Values are rounded to the nearest value the API accepts.",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateDomainSeconds,
+				StateFunc:    domainSecondsState,
+			},
+			"retry_sec": &schema.Schema{
+				Type:         schema.TypeInt,
+				Description:  "The interval, in seconds, at which a failed refresh should be retried. Values are rounded to the nearest value the API accepts.",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateDomainSeconds,
+				StateFunc:    domainSecondsState,
+			},
+			"expire_sec": &schema.Schema{
+				Type:         schema.TypeInt,
+				Description:  "The amount of time in seconds that may pass before this Domain is no longer authoritative. Values are rounded to the nearest value the API accepts.",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateDomainSeconds,
+				StateFunc:    domainSecondsState,
+			},
+			"refresh_sec": &schema.Schema{
+				Type:         schema.TypeInt,
+				Description:  "The amount of time in seconds before this Domain should be refreshed. Values are rounded to the nearest value the API accepts.",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateDomainSeconds,
+				StateFunc:    domainSecondsState,
+			},
+		},
+	}
+}
+
+func resourceLinodeDomainRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(linodego.Client)
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("Failed to parse Linode Domain ID %s as int because %s", d.Id(), err)
+	}
+
+	domain, err := client.GetDomain(int(id))
+	if err != nil {
+		if lerr, ok := err.(*linodego.Error); ok && lerr.Code == 404 {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Failed to find the specified Linode Domain because %s", err)
+	}
+
+	d.Set("domain", domain.Domain)
+	d.Set("type", domain.Type)
+	d.Set("group", domain.Group)
+	d.Set("status", domain.Status)
+	d.Set("description", domain.Description)
+	d.Set("soa_email", domain.SOAEmail)
+	d.Set("master_ips", domain.MasterIPs)
+	d.Set("axfr_ips", domain.AXfrIPs)
+	d.Set("ttl_sec", domain.TTLSec)
+	d.Set("retry_sec", domain.RetrySec)
+	d.Set("expire_sec", domain.ExpireSec)
+	d.Set("refresh_sec", domain.RefreshSec)
+
+	return nil
+}
+
+func resourceLinodeDomainCreate(d *schema.ResourceData, meta interface{}) error {
+	client, ok := meta.(linodego.Client)
+	if !ok {
+		return fmt.Errorf("Invalid Client when creating Linode Domain")
+	}
+
+	createOpts := linodego.DomainCreateOptions{
+		Domain:      d.Get("domain").(string),
+		Type:        d.Get("type").(string),
+		Group:       d.Get("group").(string),
+		Status:      d.Get("status").(string),
+		Description: d.Get("description").(string),
+		SOAEmail:    d.Get("soa_email").(string),
+		MasterIPs:   expandStringList(d.Get("master_ips").(*schema.Set).List()),
+		AXfrIPs:     expandStringList(d.Get("axfr_ips").(*schema.Set).List()),
+		TTLSec:      d.Get("ttl_sec").(int),
+		RetrySec:    d.Get("retry_sec").(int),
+		ExpireSec:   d.Get("expire_sec").(int),
+		RefreshSec:  d.Get("refresh_sec").(int),
+	}
+	domain, err := client.CreateDomain(&createOpts)
+	if err != nil {
+		return fmt.Errorf("Failed to create a Linode Domain %s because %s", d.Get("domain"), err)
+	}
+	d.SetId(fmt.Sprintf("%d", domain.ID))
+
+	return resourceLinodeDomainRead(d, meta)
+}
+
+func resourceLinodeDomainUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(linodego.Client)
+
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("Failed to parse Linode Domain ID %s as int because %s", d.Id(), err)
+	}
+
+	domain, err := client.GetDomain(int(id))
+	if err != nil {
+		return fmt.Errorf("Failed to fetch data about the current Linode Domain because %s", err)
+	}
+
+	updateOpts := domain.GetUpdateOptions()
+	updateOpts.Domain = d.Get("domain").(string)
+	updateOpts.Type = d.Get("type").(string)
+	updateOpts.Group = d.Get("group").(string)
+	updateOpts.Status = d.Get("status").(string)
+	updateOpts.Description = d.Get("description").(string)
+	updateOpts.SOAEmail = d.Get("soa_email").(string)
+	updateOpts.MasterIPs = expandStringList(d.Get("master_ips").(*schema.Set).List())
+	updateOpts.AXfrIPs = expandStringList(d.Get("axfr_ips").(*schema.Set).List())
+	updateOpts.TTLSec = d.Get("ttl_sec").(int)
+	updateOpts.RetrySec = d.Get("retry_sec").(int)
+	updateOpts.ExpireSec = d.Get("expire_sec").(int)
+	updateOpts.RefreshSec = d.Get("refresh_sec").(int)
+
+	if _, err = client.UpdateDomain(domain.ID, updateOpts); err != nil {
+		return fmt.Errorf("Failed to update Linode Domain %d because %s", domain.ID, err)
+	}
+
+	return resourceLinodeDomainRead(d, meta)
+}
+
+func resourceLinodeDomainDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(linodego.Client)
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("Failed to parse Linode Domain ID %s as int", d.Id())
+	}
+	err = client.DeleteDomain(int(id))
+	if err != nil {
+		return fmt.Errorf("Failed to delete Linode Domain %d because %s", id, err)
+	}
+	return nil
+}
+
+// resourceLinodeDomainCustomizeDiff requires an SOA email for master Domains and master IPs for slave Domains
+func resourceLinodeDomainCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("type") {
+		return nil
+	}
+
+	switch d.Get("type").(string) {
+	case "master":
+		if d.NewValueKnown("soa_email") && d.Get("soa_email").(string) == "" {
+			return fmt.Errorf("soa_email is required for master Linode Domain %s", d.Get("domain"))
+		}
+	case "slave":
+		if d.NewValueKnown("master_ips") && d.Get("master_ips").(*schema.Set).Len() == 0 {
+			return fmt.Errorf("master_ips is required for slave Linode Domain %s", d.Get("domain"))
+		}
+	}
+	return nil
+}
+
+// snapDomainSeconds rounds a number of seconds to the nearest value the API accepts, preferring the larger on a tie.
+// Only an explicit 0 keeps the API default.
+func snapDomainSeconds(seconds int) int {
+	if seconds <= 0 {
+		return 0
+	}
+
+	snapped := domainSecondsAllowed[1]
+	for _, allowed := range domainSecondsAllowed[1:] {
+		if abs(allowed-seconds) <= abs(snapped-seconds) {
+			snapped = allowed
+		}
+	}
+	return snapped
+}
+
+// domainSecondsState stores the number of seconds the API will keep for a configured value
+func domainSecondsState(val interface{}) string {
+	switch v := val.(type) {
+	case int:
+		return strconv.Itoa(snapDomainSeconds(v))
+	case string:
+		if seconds, err := strconv.Atoi(v); err == nil {
+			return strconv.Itoa(snapDomainSeconds(seconds))
+		}
+		return v
+	}
+	return fmt.Sprintf("%v", val)
+}
+
+// validateDomainSeconds rejects negative durations and warns when a value will be rounded
+func validateDomainSeconds(v interface{}, k string) (ws []string, es []error) {
+	seconds := v.(int)
+	if seconds < 0 {
+		es = append(es, fmt.Errorf("%q must not be negative, got %d", k, seconds))
+		return
+	}
+	if snapped := snapDomainSeconds(seconds); snapped != seconds {
+		ws = append(ws, fmt.Sprintf("%q of %d is not accepted by the API and will be rounded to %d", k, seconds, snapped))
+	}
+	return
+}
+
+// abs returns the absolute value of an int
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
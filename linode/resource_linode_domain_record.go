@@ -0,0 +1,385 @@
+package linode
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/chiefy/linodego"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// domainRecordTypeFields lists the type specific attributes each record type requires.
+// Type specific attributes that are not listed for a record type must be left unset.
+var domainRecordTypeFields = map[string][]string{
+	"MX":  []string{"priority"},
+	"SRV": []string{"priority", "weight", "port", "service", "protocol"},
+	"CAA": []string{"tag"},
+}
+
+func resourceLinodeDomainRecord() *schema.Resource {
+	return &schema.Resource{
+		Create:        resourceLinodeDomainRecordCreate,
+		Read:          resourceLinodeDomainRecordRead,
+		Update:        resourceLinodeDomainRecordUpdate,
+		Delete:        resourceLinodeDomainRecordDelete,
+		CustomizeDiff: resourceLinodeDomainRecordCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			State: resourceLinodeDomainRecordImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"domain_id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Description: "The ID of the Domain to access.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"type": &schema.Schema{
+				Type:         schema.TypeString,
+				Description:  "The type of Record this is in the DNS system. (A, AAAA, CNAME, MX, TXT, SRV, CAA, NS)",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateOneOf("A", "AAAA", "CNAME", "MX", "TXT", "SRV", "CAA", "NS"),
+			},
+			"name": &schema.Schema{
+				Type:             schema.TypeString,
+				Description:      "The name of this Record, relative to the Domain. Leave empty or use @ for the Domain itself. SRV Record names are generated from the service and protocol.",
+				Optional:         true,
+				Computed:         true,
+				DiffSuppressFunc: suppressDomainRecordNameDiff,
+			},
+			"target": &schema.Schema{
+				Type:             schema.TypeString,
+				Description:      "The target for this Record. This is an IP address for A and AAAA Records, a host name for CNAME, MX, NS and SRV Records, and the value of TXT and CAA Records. Use @ for the Domain itself.",
+				Required:         true,
+				DiffSuppressFunc: suppressDomainRecordTargetDiff,
+			},
+			"priority": &schema.Schema{
+				Type:         schema.TypeInt,
+				Description:  "The priority of the target host. Lower values are preferred. Required for MX and SRV Records. (0-255)",
+				Optional:     true,
+				ValidateFunc: validateDomainRecordPriority,
+			},
+			"weight": &schema.Schema{
+				Type:         schema.TypeInt,
+				Description:  "The relative weight of this Record among Records with the same priority. Required for SRV Records. (0-65535)",
+				Optional:     true,
+				ValidateFunc: validateDomainRecordPort,
+			},
+			"port": &schema.Schema{
+				Type:         schema.TypeInt,
+				Description:  "The port this Record points to. Required for SRV Records. (0-65535)",
+				Optional:     true,
+				ValidateFunc: validateDomainRecordPort,
+			},
+			"service": &schema.Schema{
+				Type:         schema.TypeString,
+				Description:  "The service this Record identifies, without the leading underscore. Required for SRV Records.",
+				Optional:     true,
+				ValidateFunc: validateDomainRecordServiceLabel,
+			},
+			"protocol": &schema.Schema{
+				Type:         schema.TypeString,
+				Description:  "The protocol the service of this Record communicates with, without the leading underscore. Required for SRV Records.",
+				Optional:     true,
+				ValidateFunc: validateDomainRecordServiceLabel,
+			},
+			"tag": &schema.Schema{
+				Type:         schema.TypeString,
+				Description:  "The tag portion of a CAA Record. Required for CAA Records. (issue, issuewild, iodef)",
+				Optional:     true,
+				ValidateFunc: validateOneOf("issue", "issuewild", "iodef"),
+			},
+			"ttl_sec": &schema.Schema{
+				Type:         schema.TypeInt,
+				Description:  "The amount of time in seconds that this Record may be cached by resolvers or other domain servers. Values are rounded to the nearest value the API accepts.",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateDomainSeconds,
+				StateFunc:    domainSecondsState,
+			},
+		},
+	}
+}
+
+func resourceLinodeDomainRecordRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(linodego.Client)
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("Failed to parse Linode Domain Record ID %s as int because %s", d.Id(), err)
+	}
+	domainID := d.Get("domain_id").(int)
+
+	domain, err := client.GetDomain(domainID)
+	if err != nil {
+		if lerr, ok := err.(*linodego.Error); ok && lerr.Code == 404 {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Failed to find the Linode Domain %d because %s", domainID, err)
+	}
+
+	record, err := client.GetDomainRecord(domainID, int(id))
+	if err != nil {
+		if lerr, ok := err.(*linodego.Error); ok && lerr.Code == 404 {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Failed to find the specified Linode Domain Record because %s", err)
+	}
+
+	// Keep the configured spelling of the name and target when it refers to the same host
+	if expandDomainRecordName(d.Get("name").(string), domain.Domain) != strings.ToLower(record.Name) {
+		d.Set("name", record.Name)
+	}
+	if !domainRecordTargetsEqual(record.Type, d.Get("target").(string), record.Target, domain.Domain) {
+		d.Set("target", record.Target)
+	}
+
+	d.Set("type", record.Type)
+	d.Set("priority", record.Priority)
+	d.Set("weight", record.Weight)
+	d.Set("port", record.Port)
+	d.Set("service", record.Service)
+	d.Set("protocol", record.Protocol)
+	d.Set("tag", record.Tag)
+	d.Set("ttl_sec", record.TTLSec)
+
+	return nil
+}
+
+func resourceLinodeDomainRecordCreate(d *schema.ResourceData, meta interface{}) error {
+	client, ok := meta.(linodego.Client)
+	if !ok {
+		return fmt.Errorf("Invalid Client when creating Linode Domain Record")
+	}
+	domainID := d.Get("domain_id").(int)
+	recordType := d.Get("type").(string)
+
+	domain, err := client.GetDomain(domainID)
+	if err != nil {
+		return fmt.Errorf("Failed to fetch data about the Linode Domain %d because %s", domainID, err)
+	}
+
+	createOpts := linodego.DomainRecordCreateOptions{
+		Type:     recordType,
+		Name:     expandDomainRecordName(d.Get("name").(string), domain.Domain),
+		Target:   expandDomainRecordTarget(recordType, d.Get("target").(string), domain.Domain),
+		Priority: d.Get("priority").(int),
+		Weight:   d.Get("weight").(int),
+		Port:     d.Get("port").(int),
+		Service:  d.Get("service").(string),
+		Protocol: d.Get("protocol").(string),
+		Tag:      d.Get("tag").(string),
+		TTLSec:   d.Get("ttl_sec").(int),
+	}
+	record, err := client.CreateDomainRecord(domainID, createOpts)
+	if err != nil {
+		return fmt.Errorf("Failed to create a Linode Domain Record for Domain %s because %s", domain.Domain, err)
+	}
+	d.SetId(fmt.Sprintf("%d", record.ID))
+
+	return resourceLinodeDomainRecordRead(d, meta)
+}
+
+func resourceLinodeDomainRecordUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(linodego.Client)
+
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("Failed to parse Linode Domain Record ID %s as int because %s", d.Id(), err)
+	}
+	domainID := d.Get("domain_id").(int)
+	recordType := d.Get("type").(string)
+
+	domain, err := client.GetDomain(domainID)
+	if err != nil {
+		return fmt.Errorf("Failed to fetch data about the Linode Domain %d because %s", domainID, err)
+	}
+
+	record, err := client.GetDomainRecord(domainID, int(id))
+	if err != nil {
+		return fmt.Errorf("Failed to fetch data about the current Linode Domain Record because %s", err)
+	}
+
+	updateOpts := record.GetUpdateOptions()
+	if recordType != "SRV" {
+		updateOpts.Name = expandDomainRecordName(d.Get("name").(string), domain.Domain)
+	}
+	updateOpts.Target = expandDomainRecordTarget(recordType, d.Get("target").(string), domain.Domain)
+	updateOpts.Priority = d.Get("priority").(int)
+	updateOpts.Weight = d.Get("weight").(int)
+	updateOpts.Port = d.Get("port").(int)
+	updateOpts.Service = d.Get("service").(string)
+	updateOpts.Protocol = d.Get("protocol").(string)
+	updateOpts.Tag = d.Get("tag").(string)
+	updateOpts.TTLSec = d.Get("ttl_sec").(int)
+
+	if _, err = client.UpdateDomainRecord(domainID, record.ID, updateOpts); err != nil {
+		return fmt.Errorf("Failed to update Linode Domain Record %d because %s", record.ID, err)
+	}
+
+	return resourceLinodeDomainRecordRead(d, meta)
+}
+
+func resourceLinodeDomainRecordDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(linodego.Client)
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("Failed to parse Linode Domain Record ID %s as int", d.Id())
+	}
+	err = client.DeleteDomainRecord(d.Get("domain_id").(int), int(id))
+	if err != nil {
+		return fmt.Errorf("Failed to delete Linode Domain Record %d because %s", id, err)
+	}
+	return nil
+}
+
+// resourceLinodeDomainRecordImport imports a Domain Record from a domainID,recordID ID
+func resourceLinodeDomainRecordImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	ids, err := parseCompositeID(d.Id(), 2)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to import Linode Domain Record %s, expected domainID,recordID: %s", d.Id(), err)
+	}
+
+	d.SetId(strconv.Itoa(ids[1]))
+	d.Set("domain_id", ids[0])
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceLinodeDomainRecordCustomizeDiff checks that the attributes of a Record match its type
+func resourceLinodeDomainRecordCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("type") {
+		return nil
+	}
+	recordType := d.Get("type").(string)
+	required := domainRecordTypeFields[recordType]
+
+	for _, field := range []string{"priority", "weight", "port", "service", "protocol", "tag"} {
+		if !d.NewValueKnown(field) {
+			continue
+		}
+		v, set := d.GetOkExists(field)
+		if s, ok := v.(string); ok {
+			set = s != ""
+		}
+
+		isRequired := false
+		for _, r := range required {
+			isRequired = isRequired || r == field
+		}
+
+		if isRequired && !set {
+			return fmt.Errorf("%s is required for %s Records", field, recordType)
+		}
+		if !isRequired && set && v != 0 && v != "" {
+			return fmt.Errorf("%s can not be set for %s Records", field, recordType)
+		}
+	}
+
+	if recordType == "SRV" && d.HasChange("name") && d.Get("name").(string) != "" {
+		return fmt.Errorf("name can not be set for SRV Records, it is generated from service and protocol")
+	}
+
+	if d.NewValueKnown("target") {
+		target := d.Get("target").(string)
+		ip := net.ParseIP(target)
+		switch {
+		case recordType == "A" && (ip == nil || ip.To4() == nil) && target != "[remote_addr]":
+			return fmt.Errorf("target must be an IPv4 address for A Records, got %s", target)
+		case recordType == "AAAA" && (ip == nil || ip.To4() != nil):
+			return fmt.Errorf("target must be an IPv6 address for AAAA Records, got %s", target)
+		}
+	}
+
+	return nil
+}
+
+// isDomainRecordHostTarget reports whether the target of a record type is a host name
+func isDomainRecordHostTarget(recordType string) bool {
+	switch recordType {
+	case "CNAME", "MX", "NS", "SRV":
+		return true
+	}
+	return false
+}
+
+// expandDomainRecordName converts a configured name into the name relative to the zone that the API stores
+func expandDomainRecordName(name string, zone string) string {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	zone = strings.ToLower(zone)
+
+	if name == "@" || (zone != "" && name == zone) {
+		return ""
+	}
+	if zone != "" {
+		name = strings.TrimSuffix(name, "."+zone)
+	}
+	return name
+}
+
+// expandDomainRecordTarget converts a configured target into the target that the API stores
+func expandDomainRecordTarget(recordType string, target string, zone string) string {
+	if !isDomainRecordHostTarget(recordType) {
+		return target
+	}
+	if target == "@" {
+		return zone
+	}
+	return strings.TrimSuffix(target, ".")
+}
+
+// domainRecordTargetsEqual reports whether two targets of a record type refer to the same value
+func domainRecordTargetsEqual(recordType string, a string, b string, zone string) bool {
+	a = expandDomainRecordTarget(recordType, a, zone)
+	b = expandDomainRecordTarget(recordType, b, zone)
+
+	switch {
+	case recordType == "A" || recordType == "AAAA":
+		if ipA, ipB := net.ParseIP(a), net.ParseIP(b); ipA != nil && ipB != nil {
+			return ipA.Equal(ipB)
+		}
+	case isDomainRecordHostTarget(recordType):
+		return strings.EqualFold(a, b)
+	}
+	return a == b
+}
+
+// suppressDomainRecordNameDiff ignores differences in names that only differ by a trailing dot, @ or case
+func suppressDomainRecordNameDiff(k, old, new string, d *schema.ResourceData) bool {
+	return expandDomainRecordName(old, "") == expandDomainRecordName(new, "")
+}
+
+// suppressDomainRecordTargetDiff ignores differences in targets that only differ by a trailing dot, case or IP notation
+func suppressDomainRecordTargetDiff(k, old, new string, d *schema.ResourceData) bool {
+	return domainRecordTargetsEqual(d.Get("type").(string), old, new, "")
+}
+
+// validateDomainRecordPriority checks that a Record priority is within the range the API accepts
+func validateDomainRecordPriority(v interface{}, k string) (ws []string, es []error) {
+	priority := v.(int)
+	if priority < 0 || priority > 255 {
+		es = append(es, fmt.Errorf("%q must be between 0 and 255, got %d", k, priority))
+	}
+	return
+}
+
+// validateDomainRecordPort checks that a Record port or weight is within the range the API accepts
+func validateDomainRecordPort(v interface{}, k string) (ws []string, es []error) {
+	port := v.(int)
+	if port < 0 || port > 65535 {
+		es = append(es, fmt.Errorf("%q must be between 0 and 65535, got %d", k, port))
+	}
+	return
+}
+
+// validateDomainRecordServiceLabel checks that an SRV service or protocol is given without its leading underscore
+func validateDomainRecordServiceLabel(v interface{}, k string) (ws []string, es []error) {
+	label := v.(string)
+	if strings.HasPrefix(label, "_") {
+		es = append(es, fmt.Errorf("%q must be given without the leading underscore, got %s", k, label))
+	}
+	return
+}
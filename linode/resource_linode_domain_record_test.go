@@ -0,0 +1,292 @@
+package linode
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/chiefy/linodego"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccLinodeDomainRecordBasic(t *testing.T) {
+	t.Parallel()
+
+	resName := "linode_domain_record.foobar"
+	var domainName = fmt.Sprintf("tf-test-%s.example", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLinodeDomainRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckLinodeDomainRecordConfigBasic(domainName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeDomainRecordExists,
+					resource.TestCheckResourceAttr(resName, "type", "A"),
+					resource.TestCheckResourceAttr(resName, "name", "www"),
+					resource.TestCheckResourceAttr(resName, "target", "192.0.2.1"),
+				),
+			},
+
+			resource.TestStep{
+				ResourceName:      resName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccStateIDDomainRecord,
+			},
+		},
+	})
+}
+
+func TestAccLinodeDomainRecordUpdate(t *testing.T) {
+	t.Parallel()
+
+	resName := "linode_domain_record.foobar"
+	var domainName = fmt.Sprintf("tf-test-%s.example", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLinodeDomainRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckLinodeDomainRecordConfigBasic(domainName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeDomainRecordExists,
+					resource.TestCheckResourceAttr(resName, "target", "192.0.2.1"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccCheckLinodeDomainRecordConfigUpdates(domainName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeDomainRecordExists,
+					resource.TestCheckResourceAttr(resName, "name", "web"),
+					resource.TestCheckResourceAttr(resName, "target", "192.0.2.2"),
+					resource.TestCheckResourceAttr(resName, "ttl_sec", "300"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccLinodeDomainRecordTypes(t *testing.T) {
+	t.Parallel()
+
+	var domainName = fmt.Sprintf("tf-test-%s.example", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLinodeDomainRecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckLinodeDomainRecordConfigTypes(domainName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeDomainRecordExists,
+					// Trailing dots and @ are kept as configured
+					resource.TestCheckResourceAttr("linode_domain_record.mx", "target", "mail."+domainName+"."),
+					resource.TestCheckResourceAttr("linode_domain_record.mx", "priority", "10"),
+					resource.TestCheckResourceAttr("linode_domain_record.cname", "target", "@"),
+					resource.TestCheckResourceAttr("linode_domain_record.srv", "port", "5060"),
+					resource.TestCheckResourceAttrSet("linode_domain_record.srv", "name"),
+					resource.TestCheckResourceAttr("linode_domain_record.caa", "tag", "issue"),
+				),
+			},
+		},
+	})
+}
+
+func TestExpandDomainRecordName(t *testing.T) {
+	cases := []struct {
+		name     string
+		zone     string
+		expected string
+	}{
+		{"www", "example.com", "www"},
+		{"WWW", "example.com", "www"},
+		{"www.example.com", "example.com", "www"},
+		{"www.example.com.", "example.com", "www"},
+		{"www.Example.COM.", "example.com", "www"},
+		{"a.b.example.com", "example.com", "a.b"},
+		{"@", "example.com", ""},
+		{"", "example.com", ""},
+		{"example.com", "example.com", ""},
+		{"example.com.", "example.com", ""},
+		{"www.example.org", "example.com", "www.example.org"},
+		{"wwwexample.com", "example.com", "wwwexample.com"},
+		{"www.", "", "www"},
+		{"@", "", ""},
+	}
+
+	for _, c := range cases {
+		if name := expandDomainRecordName(c.name, c.zone); name != c.expected {
+			t.Errorf("expandDomainRecordName(%q, %q) = %q, expected %q", c.name, c.zone, name, c.expected)
+		}
+	}
+}
+
+func TestDomainRecordTargetsEqual(t *testing.T) {
+	cases := []struct {
+		recordType string
+		a          string
+		b          string
+		zone       string
+		expected   bool
+	}{
+		{"A", "192.0.2.1", "192.0.2.1", "", true},
+		{"A", "192.0.2.1", "192.0.2.2", "", false},
+		{"A", "[remote_addr]", "[remote_addr]", "", true},
+		{"A", "[remote_addr]", "192.0.2.1", "", false},
+		{"AAAA", "2600:3c01::1", "2600:3c01:0:0:0:0:0:1", "", true},
+		{"AAAA", "2600:3C01::1", "2600:3c01::1", "", true},
+		{"AAAA", "2600:3c01::1", "2600:3c01::2", "", false},
+		{"CNAME", "www.example.com.", "www.example.com", "", true},
+		{"CNAME", "WWW.Example.com", "www.example.com", "", true},
+		{"CNAME", "www.example.com", "www.example.org", "", false},
+		{"CNAME", "@", "example.com", "example.com", true},
+		{"CNAME", "@", "example.org", "example.com", false},
+		{"MX", "mail.example.com.", "mail.example.com", "", true},
+		{"MX", "mail.example.com", "mx.example.com", "", false},
+		{"NS", "ns1.linode.com.", "NS1.LINODE.COM", "", true},
+		{"SRV", "sip.example.com", "sip.example.com.", "", true},
+		{"TXT", "v=spf1 -all", "v=spf1 -all", "", true},
+		{"TXT", "Hello", "hello", "", false},
+		{"TXT", "example.com.", "example.com", "", false},
+		{"CAA", "letsencrypt.org", "letsencrypt.org.", "", false},
+	}
+
+	for _, c := range cases {
+		if equal := domainRecordTargetsEqual(c.recordType, c.a, c.b, c.zone); equal != c.expected {
+			t.Errorf("domainRecordTargetsEqual(%q, %q, %q, %q) = %t, expected %t", c.recordType, c.a, c.b, c.zone, equal, c.expected)
+		}
+	}
+}
+
+func testAccCheckLinodeDomainRecordExists(s *terraform.State) error {
+	client := testAccProvider.Meta().(linodego.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "linode_domain_record" {
+			continue
+		}
+
+		id, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("Failed parsing %v to int", rs.Primary.ID)
+		}
+		domainID, err := strconv.Atoi(rs.Primary.Attributes["domain_id"])
+		if err != nil {
+			return fmt.Errorf("Failed parsing %v to int", rs.Primary.Attributes["domain_id"])
+		}
+
+		_, err = client.GetDomainRecord(domainID, id)
+		if err != nil {
+			return fmt.Errorf("Error retrieving state of Domain Record %d: %s", id, err)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckLinodeDomainRecordDestroy(s *terraform.State) error {
+	client, ok := testAccProvider.Meta().(linodego.Client)
+	if !ok {
+		return fmt.Errorf("Failed to get Linode client")
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "linode_domain_record" {
+			continue
+		}
+
+		id, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("Failed parsing %v to int", rs.Primary.ID)
+		}
+		domainID, err := strconv.Atoi(rs.Primary.Attributes["domain_id"])
+		if err != nil {
+			return fmt.Errorf("Failed parsing %v to int", rs.Primary.Attributes["domain_id"])
+		}
+
+		_, err = client.GetDomainRecord(domainID, id)
+
+		if err == nil {
+			return fmt.Errorf("Linode Domain Record with id %d still exists", id)
+		}
+
+		if apiErr, ok := err.(*linodego.Error); ok && apiErr.Code != 404 {
+			return fmt.Errorf("Failed to request Linode Domain Record with id %d", id)
+		}
+	}
+
+	return nil
+}
+
+func testAccStateIDDomainRecord(s *terraform.State) (string, error) {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "linode_domain_record" {
+			continue
+		}
+		return fmt.Sprintf("%s,%s", rs.Primary.Attributes["domain_id"], rs.Primary.ID), nil
+	}
+	return "", fmt.Errorf("No linode_domain_record found in state")
+}
+
+func testAccCheckLinodeDomainRecordConfigBasic(domain string) string {
+	return testAccCheckLinodeDomainConfigBasic(domain) + `
+resource "linode_domain_record" "foobar" {
+	domain_id = "${linode_domain.foobar.id}"
+	type = "A"
+	name = "www"
+	target = "192.0.2.1"
+}`
+}
+
+func testAccCheckLinodeDomainRecordConfigUpdates(domain string) string {
+	return testAccCheckLinodeDomainConfigBasic(domain) + `
+resource "linode_domain_record" "foobar" {
+	domain_id = "${linode_domain.foobar.id}"
+	type = "A"
+	name = "web"
+	target = "192.0.2.2"
+	ttl_sec = 300
+}`
+}
+
+func testAccCheckLinodeDomainRecordConfigTypes(domain string) string {
+	return testAccCheckLinodeDomainConfigBasic(domain) + fmt.Sprintf(`
+resource "linode_domain_record" "mx" {
+	domain_id = "${linode_domain.foobar.id}"
+	type = "MX"
+	target = "mail.%s."
+	priority = 10
+}
+
+resource "linode_domain_record" "cname" {
+	domain_id = "${linode_domain.foobar.id}"
+	type = "CNAME"
+	name = "www"
+	target = "@"
+}
+
+resource "linode_domain_record" "srv" {
+	domain_id = "${linode_domain.foobar.id}"
+	type = "SRV"
+	service = "sip"
+	protocol = "tcp"
+	target = "sip.%s"
+	priority = 10
+	weight = 5
+	port = 5060
+}
+
+resource "linode_domain_record" "caa" {
+	domain_id = "${linode_domain.foobar.id}"
+	type = "CAA"
+	tag = "issue"
+	target = "letsencrypt.org"
+}`, domain, domain)
+}
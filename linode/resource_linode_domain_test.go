@@ -0,0 +1,210 @@
+package linode
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/chiefy/linodego"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccLinodeDomainBasic(t *testing.T) {
+	t.Parallel()
+
+	resName := "linode_domain.foobar"
+	var domainName = fmt.Sprintf("tf-test-%s.example", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLinodeDomainDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckLinodeDomainConfigBasic(domainName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeDomainExists,
+					resource.TestCheckResourceAttr(resName, "domain", domainName),
+					resource.TestCheckResourceAttr(resName, "type", "master"),
+					resource.TestCheckResourceAttr(resName, "soa_email", "example@"+domainName),
+					resource.TestCheckResourceAttr(resName, "status", "active"),
+					// 3500 is snapped to the nearest value the API accepts
+					resource.TestCheckResourceAttr(resName, "ttl_sec", "3600"),
+				),
+			},
+
+			resource.TestStep{
+				ResourceName:      resName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccLinodeDomainUpdate(t *testing.T) {
+	t.Parallel()
+
+	resName := "linode_domain.foobar"
+	var domainName = fmt.Sprintf("tf-test-%s.example", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLinodeDomainDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckLinodeDomainConfigBasic(domainName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeDomainExists,
+					resource.TestCheckResourceAttr(resName, "domain", domainName),
+				),
+			},
+			resource.TestStep{
+				Config: testAccCheckLinodeDomainConfigUpdates(domainName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeDomainExists,
+					resource.TestCheckResourceAttr(resName, "description", "updated"),
+					resource.TestCheckResourceAttr(resName, "refresh_sec", "86400"),
+					resource.TestCheckResourceAttr(resName, "axfr_ips.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccLinodeDomainSlave(t *testing.T) {
+	t.Parallel()
+
+	resName := "linode_domain.foobar"
+	var domainName = fmt.Sprintf("tf-test-%s.example", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLinodeDomainDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckLinodeDomainConfigSlave(domainName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeDomainExists,
+					resource.TestCheckResourceAttr(resName, "type", "slave"),
+					resource.TestCheckResourceAttr(resName, "master_ips.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestSnapDomainSeconds(t *testing.T) {
+	cases := []struct {
+		seconds  int
+		expected int
+	}{
+		{-300, 0},
+		{0, 0},
+		{1, 300},
+		{300, 300},
+		{1949, 300},
+		{1950, 3600}, // Halfway between 300 and 3600 rounds up
+		{3601, 3600},
+		{5400, 7200},
+		{604800, 604800},
+		{2419200, 2419200},
+		{2419201, 2419200},
+		{100000000, 2419200},
+	}
+
+	for _, c := range cases {
+		if snapped := snapDomainSeconds(c.seconds); snapped != c.expected {
+			t.Errorf("snapDomainSeconds(%d) = %d, expected %d", c.seconds, snapped, c.expected)
+		}
+	}
+}
+
+func testAccCheckLinodeDomainExists(s *terraform.State) error {
+	client := testAccProvider.Meta().(linodego.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "linode_domain" {
+			continue
+		}
+
+		id, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("Failed parsing %v to int", rs.Primary.ID)
+		}
+
+		_, err = client.GetDomain(id)
+		if err != nil {
+			return fmt.Errorf("Error retrieving state of Domain %s: %s", rs.Primary.Attributes["domain"], err)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckLinodeDomainDestroy(s *terraform.State) error {
+	client, ok := testAccProvider.Meta().(linodego.Client)
+	if !ok {
+		return fmt.Errorf("Failed to get Linode client")
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "linode_domain" {
+			continue
+		}
+
+		id, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("Failed parsing %v to int", rs.Primary.ID)
+		}
+
+		_, err = client.GetDomain(id)
+
+		if err == nil {
+			return fmt.Errorf("Linode Domain with id %d still exists", id)
+		}
+
+		if apiErr, ok := err.(*linodego.Error); ok && apiErr.Code != 404 {
+			return fmt.Errorf("Failed to request Linode Domain with id %d", id)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckLinodeDomainConfigBasic(domain string) string {
+	return fmt.Sprintf(`
+resource "linode_domain" "foobar" {
+	domain = "%s"
+	type = "master"
+	status = "active"
+	soa_email = "example@%s"
+	ttl_sec = 3500
+}`, domain, domain)
+}
+
+func testAccCheckLinodeDomainConfigUpdates(domain string) string {
+	return fmt.Sprintf(`
+resource "linode_domain" "foobar" {
+	domain = "%s"
+	type = "master"
+	status = "active"
+	description = "updated"
+	soa_email = "example@%s"
+	ttl_sec = 3500
+	refresh_sec = 86400
+	axfr_ips = ["192.0.2.1"]
+}`, domain, domain)
+}
+
+func testAccCheckLinodeDomainConfigSlave(domain string) string {
+	return fmt.Sprintf(`
+resource "linode_domain" "foobar" {
+	domain = "%s"
+	type = "slave"
+	master_ips = ["192.0.2.1", "192.0.2.2"]
+}`, domain)
+}
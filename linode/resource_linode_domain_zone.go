@@ -0,0 +1,752 @@
+package linode
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/chiefy/linodego"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceLinodeDomainZone() *schema.Resource {
+	return &schema.Resource{
+		Create:        resourceLinodeDomainZoneCreate,
+		Read:          resourceLinodeDomainZoneRead,
+		Update:        resourceLinodeDomainZoneUpdate,
+		Delete:        resourceLinodeDomainZoneDelete,
+		CustomizeDiff: resourceLinodeDomainZoneCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"domain_id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Description: "The ID of the Domain whose Records are managed.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"zone_file": &schema.Schema{
+				Type:          schema.TypeString,
+				Description:   "The Records of the Domain in BIND zone file syntax. SOA records and the NS records of Linode's name servers are ignored.",
+				Optional:      true,
+				ConflictsWith: []string{"record"},
+			},
+			"record": &schema.Schema{
+				Type:          schema.TypeSet,
+				Description:   "The Records of the Domain. When zone_file is used, these are the Records parsed from it.",
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"zone_file"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:        schema.TypeString,
+							Description: "The name of this Record, relative to the Domain. Leave empty or use @ for the Domain itself.",
+							Optional:    true,
+						},
+						"type": &schema.Schema{
+							Type:         schema.TypeString,
+							Description:  "The type of Record this is in the DNS system. (A, AAAA, CNAME, MX, TXT, SRV, CAA, NS)",
+							Required:     true,
+							ValidateFunc: validateOneOf("A", "AAAA", "CNAME", "MX", "TXT", "SRV", "CAA", "NS"),
+						},
+						"target": &schema.Schema{
+							Type:        schema.TypeString,
+							Description: "The target for this Record.",
+							Required:    true,
+						},
+						"priority": &schema.Schema{
+							Type:         schema.TypeInt,
+							Description:  "The priority of the target host. Used by MX and SRV Records. (0-255)",
+							Optional:     true,
+							ValidateFunc: validateDomainRecordPriority,
+						},
+						"weight": &schema.Schema{
+							Type:         schema.TypeInt,
+							Description:  "The relative weight of this Record. Used by SRV Records. (0-65535)",
+							Optional:     true,
+							ValidateFunc: validateDomainRecordPort,
+						},
+						"port": &schema.Schema{
+							Type:         schema.TypeInt,
+							Description:  "The port this Record points to. Used by SRV Records. (0-65535)",
+							Optional:     true,
+							ValidateFunc: validateDomainRecordPort,
+						},
+						"service": &schema.Schema{
+							Type:         schema.TypeString,
+							Description:  "The service this Record identifies, without the leading underscore. Used by SRV Records.",
+							Optional:     true,
+							ValidateFunc: validateDomainRecordServiceLabel,
+						},
+						"protocol": &schema.Schema{
+							Type:         schema.TypeString,
+							Description:  "The protocol the service of this Record communicates with, without the leading underscore. Used by SRV Records.",
+							Optional:     true,
+							ValidateFunc: validateDomainRecordServiceLabel,
+						},
+						"tag": &schema.Schema{
+							Type:        schema.TypeString,
+							Description: "The tag portion of a CAA Record. (issue, issuewild, iodef)",
+							Optional:    true,
+						},
+						"ttl_sec": &schema.Schema{
+							Type:         schema.TypeInt,
+							Description:  "The amount of time in seconds that this Record may be cached. Values are rounded to the nearest value the API accepts.",
+							Optional:     true,
+							ValidateFunc: validateDomainSeconds,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// domainZoneRecord is a Record in the canonical form used to compare the configured zone with the Records of a Domain
+type domainZoneRecord struct {
+	Name     string
+	Type     string
+	Target   string
+	Priority int
+	Weight   int
+	Port     int
+	Service  string
+	Protocol string
+	Tag      string
+	TTLSec   int
+}
+
+func resourceLinodeDomainZoneRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(linodego.Client)
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("Failed to parse Linode Domain ID %s as int because %s", d.Id(), err)
+	}
+
+	domain, err := client.GetDomain(int(id))
+	if err != nil {
+		if lerr, ok := err.(*linodego.Error); ok && lerr.Code == 404 {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Failed to find the Linode Domain %d because %s", id, err)
+	}
+
+	records, err := client.ListDomainRecords(domain.ID, nil)
+	if err != nil {
+		return fmt.Errorf("Failed to list the Records of Linode Domain %s because %s", domain.Domain, err)
+	}
+
+	current := make([]domainZoneRecord, 0, len(records))
+	for _, record := range records {
+		current = append(current, domainZoneRecordFromAPI(record).canonical(domain.Domain))
+	}
+
+	d.Set("domain_id", domain.ID)
+	d.Set("record", flattenDomainZoneRecords(current))
+
+	return nil
+}
+
+func resourceLinodeDomainZoneCreate(d *schema.ResourceData, meta interface{}) error {
+	client, ok := meta.(linodego.Client)
+	if !ok {
+		return fmt.Errorf("Invalid Client when creating Linode Domain Zone")
+	}
+	domainID := d.Get("domain_id").(int)
+
+	domain, err := client.GetDomain(domainID)
+	if err != nil {
+		return fmt.Errorf("Failed to fetch data about the Linode Domain %d because %s", domainID, err)
+	}
+
+	desired, err := expandDomainZoneRecords(d, domain.Domain)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%d", domain.ID))
+	if err := reconcileDomainZone(&client, domain, desired); err != nil {
+		return err
+	}
+
+	return resourceLinodeDomainZoneRead(d, meta)
+}
+
+func resourceLinodeDomainZoneUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(linodego.Client)
+	domainID := d.Get("domain_id").(int)
+
+	domain, err := client.GetDomain(domainID)
+	if err != nil {
+		return fmt.Errorf("Failed to fetch data about the Linode Domain %d because %s", domainID, err)
+	}
+
+	desired, err := expandDomainZoneRecords(d, domain.Domain)
+	if err != nil {
+		return err
+	}
+
+	if err := reconcileDomainZone(&client, domain, desired); err != nil {
+		return err
+	}
+
+	return resourceLinodeDomainZoneRead(d, meta)
+}
+
+func resourceLinodeDomainZoneDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(linodego.Client)
+	domainID := d.Get("domain_id").(int)
+
+	domain, err := client.GetDomain(domainID)
+	if err != nil {
+		if lerr, ok := err.(*linodego.Error); ok && lerr.Code == 404 {
+			return nil
+		}
+		return fmt.Errorf("Failed to fetch data about the Linode Domain %d because %s", domainID, err)
+	}
+
+	// Every Record of the Domain is managed by this resource, so none are left behind
+	return reconcileDomainZone(&client, domain, nil)
+}
+
+// resourceLinodeDomainZoneCustomizeDiff plans the canonical Records of the zone file or record blocks,
+// so that the plan shows exactly which Records will be created and deleted
+func resourceLinodeDomainZoneCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("domain_id") || !d.NewValueKnown("zone_file") || !d.NewValueKnown("record") {
+		return d.SetNewComputed("record")
+	}
+
+	// The Records can only be planned with the name of the Domain. When it can't be fetched, because the Domain is
+	// gone or the API can't be reached, they are left to the apply instead of failing the plan.
+	client := meta.(linodego.Client)
+	domainID := d.Get("domain_id").(int)
+	domain, err := client.GetDomain(domainID)
+	if err != nil {
+		log.Printf("[WARN] Planning the Records of Linode Domain %d as computed because %s", domainID, err)
+		return d.SetNewComputed("record")
+	}
+
+	var desired []domainZoneRecord
+	if zoneFile := d.Get("zone_file").(string); zoneFile != "" {
+		desired, err = parseDomainZoneFile(zoneFile, domain.Domain)
+		if err != nil {
+			return err
+		}
+	} else {
+		for _, r := range d.Get("record").(*schema.Set).List() {
+			desired = append(desired, domainZoneRecordFromMap(r.(map[string]interface{})))
+		}
+	}
+
+	for i, record := range desired {
+		desired[i] = record.canonical(domain.Domain)
+		if err := desired[i].validate(); err != nil {
+			return err
+		}
+	}
+
+	return d.SetNew("record", flattenDomainZoneRecords(desired))
+}
+
+// expandDomainZoneRecords returns the canonical Records the zone file or record blocks describe
+func expandDomainZoneRecords(d *schema.ResourceData, zone string) ([]domainZoneRecord, error) {
+	if zoneFile := d.Get("zone_file").(string); zoneFile != "" {
+		records, err := parseDomainZoneFile(zoneFile, zone)
+		if err != nil {
+			return nil, err
+		}
+		for i, record := range records {
+			records[i] = record.canonical(zone)
+		}
+		return records, nil
+	}
+
+	var records []domainZoneRecord
+	for _, r := range d.Get("record").(*schema.Set).List() {
+		records = append(records, domainZoneRecordFromMap(r.(map[string]interface{})).canonical(zone))
+	}
+	return records, nil
+}
+
+// reconcileDomainZone makes the Records of a Domain match the desired Records.
+// Records that only changed in their target or options are updated in place, which keeps their IDs.
+func reconcileDomainZone(client *linodego.Client, domain *linodego.Domain, desired []domainZoneRecord) error {
+	records, err := client.ListDomainRecords(domain.ID, nil)
+	if err != nil {
+		return fmt.Errorf("Failed to list the Records of Linode Domain %s because %s", domain.Domain, err)
+	}
+
+	pending := append([]domainZoneRecord{}, desired...)
+	var stale []*linodego.DomainRecord
+	for _, record := range records {
+		current := domainZoneRecordFromAPI(record).canonical(domain.Domain)
+		if i := indexDomainZoneRecord(pending, func(r domainZoneRecord) bool { return r == current }); i >= 0 {
+			pending = append(pending[:i], pending[i+1:]...)
+			continue
+		}
+		stale = append(stale, record)
+	}
+
+	var missing []domainZoneRecord
+	for _, record := range pending {
+		i := -1
+		for j, s := range stale {
+			current := domainZoneRecordFromAPI(s).canonical(domain.Domain)
+			if current.Name == record.Name && current.Type == record.Type {
+				i = j
+				break
+			}
+		}
+		if i < 0 {
+			missing = append(missing, record)
+			continue
+		}
+
+		updateOpts := linodego.DomainRecordUpdateOptions(record.createOptions())
+		updateOpts.Type = ""
+		if _, err := client.UpdateDomainRecord(domain.ID, stale[i].ID, updateOpts); err != nil {
+			return fmt.Errorf("Failed to update %s Record %s of Linode Domain %s because %s", record.Type, record.Name, domain.Domain, err)
+		}
+		stale = append(stale[:i], stale[i+1:]...)
+	}
+
+	for _, record := range stale {
+		if err := client.DeleteDomainRecord(domain.ID, record.ID); err != nil {
+			return fmt.Errorf("Failed to delete %s Record %s of Linode Domain %s because %s", record.Type, record.Name, domain.Domain, err)
+		}
+	}
+
+	for _, record := range missing {
+		if _, err := client.CreateDomainRecord(domain.ID, record.createOptions()); err != nil {
+			return fmt.Errorf("Failed to create %s Record %s of Linode Domain %s because %s", record.Type, record.Name, domain.Domain, err)
+		}
+	}
+
+	return nil
+}
+
+// indexDomainZoneRecord returns the index of the first Record matching f, or -1
+func indexDomainZoneRecord(records []domainZoneRecord, f func(domainZoneRecord) bool) int {
+	for i, r := range records {
+		if f(r) {
+			return i
+		}
+	}
+	return -1
+}
+
+// domainZoneRecordFromAPI converts a Record returned by the API
+func domainZoneRecordFromAPI(record *linodego.DomainRecord) domainZoneRecord {
+	return domainZoneRecord{
+		Name:     record.Name,
+		Type:     record.Type,
+		Target:   record.Target,
+		Priority: record.Priority,
+		Weight:   record.Weight,
+		Port:     record.Port,
+		Service:  record.Service,
+		Protocol: record.Protocol,
+		Tag:      record.Tag,
+		TTLSec:   record.TTLSec,
+	}
+}
+
+// domainZoneRecordFromMap converts a record block
+func domainZoneRecordFromMap(m map[string]interface{}) domainZoneRecord {
+	return domainZoneRecord{
+		Name:     m["name"].(string),
+		Type:     m["type"].(string),
+		Target:   m["target"].(string),
+		Priority: m["priority"].(int),
+		Weight:   m["weight"].(int),
+		Port:     m["port"].(int),
+		Service:  m["service"].(string),
+		Protocol: m["protocol"].(string),
+		Tag:      m["tag"].(string),
+		TTLSec:   m["ttl_sec"].(int),
+	}
+}
+
+// flattenDomainZoneRecords converts Records into record blocks
+func flattenDomainZoneRecords(records []domainZoneRecord) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(records))
+	for _, r := range records {
+		result = append(result, map[string]interface{}{
+			"name":     r.Name,
+			"type":     r.Type,
+			"target":   r.Target,
+			"priority": r.Priority,
+			"weight":   r.Weight,
+			"port":     r.Port,
+			"service":  r.Service,
+			"protocol": r.Protocol,
+			"tag":      r.Tag,
+			"ttl_sec":  r.TTLSec,
+		})
+	}
+	return result
+}
+
+// canonical returns the Record the way the API stores it for the given zone, without attributes its type does not use
+func (r domainZoneRecord) canonical(zone string) domainZoneRecord {
+	c := domainZoneRecord{
+		Type:   strings.ToUpper(r.Type),
+		TTLSec: snapDomainSeconds(r.TTLSec),
+	}
+
+	c.Name = expandDomainRecordName(r.Name, zone)
+	c.Target = expandDomainRecordTarget(c.Type, r.Target, zone)
+
+	switch c.Type {
+	case "A", "AAAA":
+		if ip := net.ParseIP(c.Target); ip != nil {
+			c.Target = ip.String()
+		}
+	case "CNAME", "NS":
+		c.Target = strings.ToLower(c.Target)
+	case "MX":
+		c.Target = strings.ToLower(c.Target)
+		c.Priority = r.Priority
+	case "SRV":
+		// SRV Record names are generated from the service and protocol
+		c.Name = ""
+		c.Target = strings.ToLower(c.Target)
+		c.Priority = r.Priority
+		c.Weight = r.Weight
+		c.Port = r.Port
+		c.Service = strings.ToLower(strings.TrimPrefix(r.Service, "_"))
+		c.Protocol = strings.ToLower(strings.TrimPrefix(r.Protocol, "_"))
+	case "CAA":
+		c.Tag = strings.ToLower(r.Tag)
+	}
+
+	return c
+}
+
+// validate checks that a canonical Record has the attributes its type requires
+func (r domainZoneRecord) validate() error {
+	switch r.Type {
+	case "A":
+		if ip := net.ParseIP(r.Target); ip == nil || ip.To4() == nil {
+			return fmt.Errorf("target must be an IPv4 address for A Record %q, got %s", r.Name, r.Target)
+		}
+	case "AAAA":
+		if ip := net.ParseIP(r.Target); ip == nil || ip.To4() != nil {
+			return fmt.Errorf("target must be an IPv6 address for AAAA Record %q, got %s", r.Name, r.Target)
+		}
+	case "SRV":
+		if r.Service == "" || r.Protocol == "" {
+			return fmt.Errorf("service and protocol are required for SRV Record %s", r.Target)
+		}
+	case "CAA":
+		if r.Tag != "issue" && r.Tag != "issuewild" && r.Tag != "iodef" {
+			return fmt.Errorf("tag must be one of issue, issuewild, iodef for CAA Record %q, got %s", r.Name, r.Tag)
+		}
+	}
+	if r.Target == "" {
+		return fmt.Errorf("target is required for %s Record %q", r.Type, r.Name)
+	}
+	return nil
+}
+
+// createOptions converts a canonical Record into the options used to create it
+func (r domainZoneRecord) createOptions() linodego.DomainRecordCreateOptions {
+	return linodego.DomainRecordCreateOptions{
+		Type:     r.Type,
+		Name:     r.Name,
+		Target:   r.Target,
+		Priority: r.Priority,
+		Weight:   r.Weight,
+		Port:     r.Port,
+		Service:  r.Service,
+		Protocol: r.Protocol,
+		Tag:      r.Tag,
+		TTLSec:   r.TTLSec,
+	}
+}
+
+// parseDomainZoneFile parses the Records of a BIND zone file for the given zone.
+// $ORIGIN and $TTL directives, relative names, parentheses and comments are supported.
+// SOA records and the NS records of Linode's name servers are skipped, because Linode manages them.
+func parseDomainZoneFile(zoneFile string, zone string) ([]domainZoneRecord, error) {
+	zone = strings.ToLower(strings.TrimSuffix(zone, "."))
+	origin := zone
+	defaultTTL := 0
+	lastOwner := ""
+	var records []domainZoneRecord
+
+	entries, err := splitDomainZoneFileEntries(zoneFile)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		fields := entry.fields
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch strings.ToUpper(fields[0]) {
+		case "$ORIGIN":
+			if len(fields) != 2 {
+				return nil, fmt.Errorf("line %d: $ORIGIN expects a single domain name", entry.line)
+			}
+			origin = absoluteDomainZoneName(fields[1], origin)
+			continue
+		case "$TTL":
+			if len(fields) != 2 {
+				return nil, fmt.Errorf("line %d: $TTL expects a single TTL", entry.line)
+			}
+			ttl, err := parseDomainZoneTTL(fields[1])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %s", entry.line, err)
+			}
+			defaultTTL = ttl
+			continue
+		case "$INCLUDE", "$GENERATE":
+			return nil, fmt.Errorf("line %d: %s is not supported", entry.line, fields[0])
+		}
+
+		owner := lastOwner
+		if !entry.continued {
+			owner = absoluteDomainZoneName(fields[0], origin)
+			fields = fields[1:]
+		}
+		if owner == "" {
+			return nil, fmt.Errorf("line %d: the record has no owner name", entry.line)
+		}
+		lastOwner = owner
+
+		ttl := defaultTTL
+		for len(fields) > 0 {
+			if t, err := parseDomainZoneTTL(fields[0]); err == nil {
+				ttl = t
+			} else if class := strings.ToUpper(fields[0]); class != "IN" && class != "CH" && class != "HS" {
+				break
+			}
+			fields = fields[1:]
+		}
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("line %d: the record has no type", entry.line)
+		}
+
+		recordType, rdata := strings.ToUpper(fields[0]), fields[1:]
+		if recordType == "SOA" {
+			continue
+		}
+
+		record, err := parseDomainZoneRecordData(recordType, rdata, origin)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", entry.line, err)
+		}
+		if recordType == "NS" && owner == zone && strings.HasSuffix(record.Target, ".linode.com") {
+			continue
+		}
+
+		name := owner
+		if recordType == "SRV" {
+			labels := strings.SplitN(owner, ".", 3)
+			if len(labels) < 3 || !strings.HasPrefix(labels[0], "_") || !strings.HasPrefix(labels[1], "_") {
+				return nil, fmt.Errorf("line %d: SRV record owner %s must start with _service._protocol", entry.line, owner)
+			}
+			record.Service, record.Protocol, name = labels[0], labels[1], labels[2]
+			if name != zone {
+				return nil, fmt.Errorf("line %d: SRV records are only supported for the zone apex, got %s", entry.line, owner)
+			}
+		}
+
+		if name != zone && !strings.HasSuffix(name, "."+zone) {
+			return nil, fmt.Errorf("line %d: %s is outside of the zone %s", entry.line, owner, zone)
+		}
+		record.Name = name + "."
+		record.Type = recordType
+		record.TTLSec = ttl
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// parseDomainZoneRecordData converts the record data of a zone file entry into a Record with an absolute target
+func parseDomainZoneRecordData(recordType string, rdata []string, origin string) (domainZoneRecord, error) {
+	var record domainZoneRecord
+	expected := map[string]int{"A": 1, "AAAA": 1, "CNAME": 1, "NS": 1, "MX": 2, "SRV": 4, "CAA": 3}
+
+	if recordType == "TXT" {
+		if len(rdata) == 0 {
+			return record, fmt.Errorf("TXT record has no data")
+		}
+		record.Target = strings.Join(rdata, "")
+		return record, nil
+	}
+
+	n, ok := expected[recordType]
+	if !ok {
+		return record, fmt.Errorf("%s records are not supported", recordType)
+	}
+	if len(rdata) != n {
+		return record, fmt.Errorf("%s record expects %d fields of data, got %d", recordType, n, len(rdata))
+	}
+
+	ints := func(values ...string) ([]int, error) {
+		result := make([]int, 0, len(values))
+		for _, v := range values {
+			i, err := strconv.Atoi(v)
+			if err != nil {
+				return nil, fmt.Errorf("%s record has invalid number %s", recordType, v)
+			}
+			result = append(result, i)
+		}
+		return result, nil
+	}
+
+	switch recordType {
+	case "A", "AAAA":
+		record.Target = rdata[0]
+	case "CNAME", "NS":
+		record.Target = absoluteDomainZoneName(rdata[0], origin)
+	case "MX":
+		values, err := ints(rdata[0])
+		if err != nil {
+			return record, err
+		}
+		record.Priority = values[0]
+		record.Target = absoluteDomainZoneName(rdata[1], origin)
+	case "SRV":
+		values, err := ints(rdata[:3]...)
+		if err != nil {
+			return record, err
+		}
+		record.Priority, record.Weight, record.Port = values[0], values[1], values[2]
+		record.Target = absoluteDomainZoneName(rdata[3], origin)
+	case "CAA":
+		record.Tag = strings.ToLower(rdata[1])
+		record.Target = rdata[2]
+	}
+
+	return record, nil
+}
+
+// absoluteDomainZoneName expands a zone file name relative to the origin into a lowercase name without the trailing dot
+func absoluteDomainZoneName(name string, origin string) string {
+	name = strings.ToLower(name)
+	switch {
+	case name == "@":
+		return origin
+	case strings.HasSuffix(name, "."):
+		return strings.TrimSuffix(name, ".")
+	case origin == "":
+		return name
+	}
+	return name + "." + origin
+}
+
+// parseDomainZoneTTL parses a TTL in seconds or with BIND's w, d, h, m and s units
+func parseDomainZoneTTL(value string) (int, error) {
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return seconds, nil
+	}
+
+	units := map[byte]int{'w': 604800, 'd': 86400, 'h': 3600, 'm': 60, 's': 1}
+	total, current, digits := 0, 0, 0
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case c >= '0' && c <= '9':
+			current = current*10 + int(c-'0')
+			digits++
+		case units[c|0x20] != 0 && digits > 0:
+			total += current * units[c|0x20]
+			current, digits = 0, 0
+		default:
+			return 0, fmt.Errorf("invalid TTL %s", value)
+		}
+	}
+	if digits > 0 || value == "" {
+		return 0, fmt.Errorf("invalid TTL %s", value)
+	}
+	return total, nil
+}
+
+// domainZoneFileEntry is a single logical entry of a zone file
+type domainZoneFileEntry struct {
+	line      int
+	continued bool
+	fields    []string
+}
+
+// splitDomainZoneFileEntries splits a zone file into entries of fields, joining lines within parentheses,
+// removing comments and unquoting strings
+func splitDomainZoneFileEntries(zoneFile string) ([]domainZoneFileEntry, error) {
+	var entries []domainZoneFileEntry
+	var entry *domainZoneFileEntry
+	depth := 0
+
+	scanner := bufio.NewScanner(strings.NewReader(zoneFile))
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+
+		if depth == 0 {
+			if entry != nil && len(entry.fields) > 0 {
+				entries = append(entries, *entry)
+			}
+			entry = &domainZoneFileEntry{
+				line:      line,
+				continued: len(text) > 0 && (text[0] == ' ' || text[0] == '\t'),
+			}
+		}
+
+		for i := 0; i < len(text); i++ {
+			c := text[i]
+			switch {
+			case c == ';':
+				i = len(text)
+			case c == ' ' || c == '\t' || c == '\r':
+			case c == '(':
+				depth++
+			case c == ')':
+				if depth == 0 {
+					return nil, fmt.Errorf("line %d: unbalanced parentheses", line)
+				}
+				depth--
+			case c == '"':
+				var field []byte
+				for i++; i < len(text) && text[i] != '"'; i++ {
+					if text[i] == '\\' && i+1 < len(text) {
+						i++
+					}
+					field = append(field, text[i])
+				}
+				if i >= len(text) {
+					return nil, fmt.Errorf("line %d: unterminated quoted string", line)
+				}
+				entry.fields = append(entry.fields, string(field))
+			default:
+				start := i
+				for i < len(text) && !strings.ContainsRune(" \t\r;()\"", rune(text[i])) {
+					i++
+				}
+				entry.fields = append(entry.fields, text[start:i])
+				i--
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("unbalanced parentheses at the end of the zone file")
+	}
+	if entry != nil && len(entry.fields) > 0 {
+		entries = append(entries, *entry)
+	}
+
+	return entries, nil
+}
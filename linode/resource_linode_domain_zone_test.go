@@ -0,0 +1,473 @@
+package linode
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/chiefy/linodego"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccLinodeDomainZoneFile(t *testing.T) {
+	t.Parallel()
+
+	resName := "linode_domain_zone.foobar"
+	var domainName = fmt.Sprintf("tf-test-%s.example", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLinodeDomainZoneDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckLinodeDomainZoneConfigFile(domainName, `
+$TTL 1h
+@	IN	SOA	ns1.linode.com. hostmaster.example. ( 1 3600 300 1209600 3600 )
+@		NS	ns1.linode.com.
+@		MX	10 mail
+mail		A	192.0.2.1
+www	300	CNAME	@
+`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeDomainZoneRecords(3),
+					resource.TestCheckResourceAttr(resName, "record.#", "3"),
+				),
+			},
+			// Changing the zone file only touches the records that differ
+			resource.TestStep{
+				Config: testAccCheckLinodeDomainZoneConfigFile(domainName, `
+$ORIGIN `+domainName+`.
+$TTL 1h
+@		MX	10 mail
+mail		A	192.0.2.2
+`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeDomainZoneRecords(2),
+					resource.TestCheckResourceAttr(resName, "record.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccLinodeDomainZoneRecords(t *testing.T) {
+	t.Parallel()
+
+	resName := "linode_domain_zone.foobar"
+	var domainName = fmt.Sprintf("tf-test-%s.example", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLinodeDomainZoneDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckLinodeDomainZoneConfigRecords(domainName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeDomainZoneRecords(2),
+					resource.TestCheckResourceAttr(resName, "record.#", "2"),
+				),
+			},
+
+			resource.TestStep{
+				ResourceName:      resName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestParseDomainZoneFile(t *testing.T) {
+	cases := []struct {
+		description string
+		zoneFile    string
+		expected    []domainZoneRecord
+	}{
+		{
+			description: "relative, absolute and apex names",
+			zoneFile: `
+@	3600	IN	A	192.0.2.1
+www		IN	A	192.0.2.2
+mail.example.com.	IN	A	192.0.2.3
+WWW2	IN	a	192.0.2.4
+`,
+			expected: []domainZoneRecord{
+				{Name: "example.com.", Type: "A", Target: "192.0.2.1", TTLSec: 3600},
+				{Name: "www.example.com.", Type: "A", Target: "192.0.2.2"},
+				{Name: "mail.example.com.", Type: "A", Target: "192.0.2.3"},
+				{Name: "www2.example.com.", Type: "A", Target: "192.0.2.4"},
+			},
+		},
+		{
+			description: "$ORIGIN and $TTL",
+			zoneFile: `
+$TTL 1h30m
+$ORIGIN sub.example.com.
+host	IN	CNAME	target
+alias	60	CNAME	other.example.org.
+$ORIGIN example.com.
+@	MX	10	mail
+`,
+			expected: []domainZoneRecord{
+				{Name: "host.sub.example.com.", Type: "CNAME", Target: "target.sub.example.com", TTLSec: 5400},
+				{Name: "alias.sub.example.com.", Type: "CNAME", Target: "other.example.org", TTLSec: 60},
+				{Name: "example.com.", Type: "MX", Target: "mail.example.com", Priority: 10, TTLSec: 5400},
+			},
+		},
+		{
+			description: "parenthesised entries, SOA and Linode name servers",
+			zoneFile: `
+@	IN	SOA	ns1.linode.com. hostmaster.example.com. (
+		2018010101	; serial
+		14400		; refresh
+		3600 )		; retry
+@	IN	NS	ns1.linode.com.
+@	IN	NS	ns.example.org.
+@	IN	MX	( 10
+		mail )
+`,
+			expected: []domainZoneRecord{
+				{Name: "example.com.", Type: "NS", Target: "ns.example.org"},
+				{Name: "example.com.", Type: "MX", Target: "mail.example.com", Priority: 10},
+			},
+		},
+		{
+			description: "quoted TXT data",
+			zoneFile: `
+@	IN	TXT	"v=spf1 include:example.org ~all; not a comment"	; a comment
+txt	IN	TXT	"part one " "part two"
+esc	IN	TXT	"say \"hi\""
+`,
+			expected: []domainZoneRecord{
+				{Name: "example.com.", Type: "TXT", Target: "v=spf1 include:example.org ~all; not a comment"},
+				{Name: "txt.example.com.", Type: "TXT", Target: "part one part two"},
+				{Name: "esc.example.com.", Type: "TXT", Target: `say "hi"`},
+			},
+		},
+		{
+			description: "owner names carried over to indented entries",
+			zoneFile: `
+www	IN	A	192.0.2.1
+	IN	AAAA	2600:3c01::1
+`,
+			expected: []domainZoneRecord{
+				{Name: "www.example.com.", Type: "A", Target: "192.0.2.1"},
+				{Name: "www.example.com.", Type: "AAAA", Target: "2600:3c01::1"},
+			},
+		},
+		{
+			description: "SRV and CAA",
+			zoneFile: `
+_sip._tcp	300	IN	SRV	10 20 5060 sip
+_xmpp._tcp.example.com.	IN	SRV	5 0 5222 xmpp.example.org.
+@	IN	CAA	0 ISSUE "letsencrypt.org"
+`,
+			expected: []domainZoneRecord{
+				{Name: "example.com.", Type: "SRV", Target: "sip.example.com", Priority: 10, Weight: 20, Port: 5060, Service: "_sip", Protocol: "_tcp", TTLSec: 300},
+				{Name: "example.com.", Type: "SRV", Target: "xmpp.example.org", Priority: 5, Port: 5222, Service: "_xmpp", Protocol: "_tcp"},
+				{Name: "example.com.", Type: "CAA", Target: "letsencrypt.org", Tag: "issue"},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		records, err := parseDomainZoneFile(c.zoneFile, "example.com.")
+		if err != nil {
+			t.Errorf("%s: unexpected error %s", c.description, err)
+			continue
+		}
+		if !reflect.DeepEqual(records, c.expected) {
+			t.Errorf("%s: parsed\n%#v\nexpected\n%#v", c.description, records, c.expected)
+		}
+	}
+}
+
+func TestParseDomainZoneFileErrors(t *testing.T) {
+	cases := []struct {
+		zoneFile string
+		expected string
+	}{
+		{"www IN A", "line 1: A record expects 1 fields of data, got 0"},
+		{"@ 300 IN", "line 1: the record has no type"},
+		{"	IN A 192.0.2.1", "line 1: the record has no owner name"},
+		{"$TTL", "line 1: $TTL expects a single TTL"},
+		{"$TTL 1x", "line 1: invalid TTL 1x"},
+		{"$ORIGIN a. b.", "line 1: $ORIGIN expects a single domain name"},
+		{"$INCLUDE other.zone", "line 1: $INCLUDE is not supported"},
+		{"\nwww IN A 192.0.2.1 )", "line 2: unbalanced parentheses"},
+		{"@ IN MX ( 10\nmail", "unbalanced parentheses at the end of the zone file"},
+		{`@ IN TXT "open`, "line 1: unterminated quoted string"},
+		{"@ IN HINFO pc linux", "line 1: HINFO records are not supported"},
+		{"@ IN MX ten mail", "line 1: MX record has invalid number ten"},
+		{"www.example.org. IN A 192.0.2.1", "line 1: www.example.org is outside of the zone example.com"},
+		{"sip IN SRV 1 2 3 sip", "line 1: SRV record owner sip.example.com must start with _service._protocol"},
+		{"_sip._tcp.www IN SRV 1 2 3 sip", "line 1: SRV records are only supported for the zone apex, got _sip._tcp.www.example.com"},
+	}
+
+	for _, c := range cases {
+		_, err := parseDomainZoneFile(c.zoneFile, "example.com")
+		if err == nil {
+			t.Errorf("parseDomainZoneFile(%q) succeeded, expected error %q", c.zoneFile, c.expected)
+			continue
+		}
+		if err.Error() != c.expected {
+			t.Errorf("parseDomainZoneFile(%q) failed with %q, expected %q", c.zoneFile, err, c.expected)
+		}
+	}
+}
+
+func TestParseDomainZoneTTL(t *testing.T) {
+	cases := []struct {
+		value    string
+		expected int
+		valid    bool
+	}{
+		{"0", 0, true},
+		{"300", 300, true},
+		{"90s", 90, true},
+		{"1h30m", 5400, true},
+		{"1H30M", 5400, true},
+		{"2d", 172800, true},
+		{"1w1d", 691200, true},
+		{"", 0, false},
+		{"h", 0, false},
+		{"1h30", 0, false},
+		{"-5", 0, false},
+		{"1x", 0, false},
+		{"1.5h", 0, false},
+	}
+
+	for _, c := range cases {
+		ttl, err := parseDomainZoneTTL(c.value)
+		if c.valid && (err != nil || ttl != c.expected) {
+			t.Errorf("parseDomainZoneTTL(%q) = %d, %v, expected %d", c.value, ttl, err, c.expected)
+		}
+		if !c.valid && err == nil {
+			t.Errorf("parseDomainZoneTTL(%q) = %d, expected an error", c.value, ttl)
+		}
+	}
+}
+
+func TestSplitDomainZoneFileEntries(t *testing.T) {
+	entries, err := splitDomainZoneFileEntries("; header\n@ IN MX ( 10 ; priority\n\tmail )\n\n\tIN TXT \"a ( b\"\n")
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+
+	expected := []domainZoneFileEntry{
+		{line: 2, fields: []string{"@", "IN", "MX", "10", "mail"}},
+		{line: 5, continued: true, fields: []string{"IN", "TXT", "a ( b"}},
+	}
+	if !reflect.DeepEqual(entries, expected) {
+		t.Errorf("split\n%#v\nexpected\n%#v", entries, expected)
+	}
+}
+
+func TestReconcileDomainZone(t *testing.T) {
+	api := &testDomainRecordAPI{
+		records: map[int]*linodego.DomainRecord{
+			1: {ID: 1, Type: "A", Name: "www", Target: "192.0.2.1"},
+			2: {ID: 2, Type: "A", Name: "old", Target: "192.0.2.9"},
+			3: {ID: 3, Type: "MX", Name: "", Target: "mail.example.com", Priority: 10},
+		},
+		nextID: 4,
+	}
+	token := "test"
+	client := linodego.NewClient(&token, api)
+	domain := &linodego.Domain{ID: 1, Domain: "example.com"}
+
+	desired := []domainZoneRecord{
+		{Name: "www", Type: "A", Target: "192.0.2.1"},
+		{Name: "", Type: "MX", Target: "mail.example.com", Priority: 20},
+		{Name: "", Type: "TXT", Target: "hello"},
+	}
+	if err := reconcileDomainZone(&client, domain, desired); err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+
+	// The unchanged Record is kept, the MX Record is updated in place and only the stale Record is deleted
+	expectedRequests := []string{"GET", "PUT 3", "DELETE 2", "POST"}
+	if !reflect.DeepEqual(api.requests, expectedRequests) {
+		t.Errorf("requests %v, expected %v", api.requests, expectedRequests)
+	}
+	if len(api.records) != 3 || api.records[1].Target != "192.0.2.1" || api.records[3].Priority != 20 || api.records[4].Target != "hello" {
+		t.Errorf("unexpected Records after reconciling: %v", api.records)
+	}
+
+	// Reconciling again changes nothing
+	api.requests = nil
+	if err := reconcileDomainZone(&client, domain, desired); err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if !reflect.DeepEqual(api.requests, []string{"GET"}) {
+		t.Errorf("requests %v, expected only the listing", api.requests)
+	}
+}
+
+// testDomainRecordAPI serves the Records of a single Domain from memory, in place of the Linode API
+type testDomainRecordAPI struct {
+	records  map[int]*linodego.DomainRecord
+	nextID   int
+	requests []string
+}
+
+func (api *testDomainRecordAPI) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorder := httptest.NewRecorder()
+	recorder.Header().Set("Content-Type", "application/json")
+
+	var opts linodego.DomainRecordCreateOptions
+	if req.Body != nil {
+		if err := json.NewDecoder(req.Body).Decode(&opts); err != nil && err != io.EOF {
+			return nil, err
+		}
+	}
+
+	path := strings.TrimPrefix(req.URL.Path, "/v4/domains/1/records")
+	id, _ := strconv.Atoi(strings.TrimPrefix(path, "/"))
+	var response interface{}
+	switch {
+	case req.Method == "GET" && path == "":
+		api.requests = append(api.requests, "GET")
+		records := make([]*linodego.DomainRecord, 0, len(api.records))
+		for _, record := range api.records {
+			records = append(records, record)
+		}
+		sort.Slice(records, func(i, j int) bool { return records[i].ID < records[j].ID })
+		response = map[string]interface{}{"data": records, "page": 1, "pages": 1, "results": len(records)}
+	case req.Method == "POST" && path == "":
+		api.requests = append(api.requests, "POST")
+		record := testDomainRecordFromOptions(api.nextID, opts)
+		api.records[record.ID] = record
+		api.nextID++
+		response = record
+	case req.Method == "PUT" && api.records[id] != nil:
+		api.requests = append(api.requests, fmt.Sprintf("PUT %d", id))
+		record := testDomainRecordFromOptions(id, opts)
+		record.Type = api.records[id].Type
+		api.records[id] = record
+		response = record
+	case req.Method == "DELETE" && api.records[id] != nil:
+		api.requests = append(api.requests, fmt.Sprintf("DELETE %d", id))
+		delete(api.records, id)
+		response = map[string]interface{}{}
+	default:
+		recorder.WriteHeader(http.StatusNotFound)
+		response = map[string]interface{}{"errors": []map[string]string{{"reason": "Not found"}}}
+	}
+
+	if err := json.NewEncoder(recorder).Encode(response); err != nil {
+		return nil, err
+	}
+	return recorder.Result(), nil
+}
+
+func testDomainRecordFromOptions(id int, opts linodego.DomainRecordCreateOptions) *linodego.DomainRecord {
+	return &linodego.DomainRecord{
+		ID:       id,
+		Type:     opts.Type,
+		Name:     opts.Name,
+		Target:   opts.Target,
+		Priority: opts.Priority,
+		Weight:   opts.Weight,
+		Port:     opts.Port,
+		Service:  opts.Service,
+		Protocol: opts.Protocol,
+		TTLSec:   opts.TTLSec,
+		Tag:      opts.Tag,
+	}
+}
+
+func testAccCheckLinodeDomainZoneRecords(count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(linodego.Client)
+
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "linode_domain_zone" {
+				continue
+			}
+
+			id, err := strconv.Atoi(rs.Primary.ID)
+			if err != nil {
+				return fmt.Errorf("Failed parsing %v to int", rs.Primary.ID)
+			}
+
+			records, err := client.ListDomainRecords(id, nil)
+			if err != nil {
+				return fmt.Errorf("Error retrieving the Records of Domain %d: %s", id, err)
+			}
+			if len(records) != count {
+				return fmt.Errorf("Expected %d Records in Domain %d, found %d", count, id, len(records))
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckLinodeDomainZoneDestroy(s *terraform.State) error {
+	client, ok := testAccProvider.Meta().(linodego.Client)
+	if !ok {
+		return fmt.Errorf("Failed to get Linode client")
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "linode_domain_zone" {
+			continue
+		}
+
+		id, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("Failed parsing %v to int", rs.Primary.ID)
+		}
+
+		records, err := client.ListDomainRecords(id, nil)
+		if err == nil && len(records) > 0 {
+			return fmt.Errorf("Linode Domain with id %d still has %d Records", id, len(records))
+		}
+
+		if apiErr, ok := err.(*linodego.Error); ok && apiErr.Code != 404 {
+			return fmt.Errorf("Failed to request the Records of Linode Domain with id %d", id)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckLinodeDomainZoneConfigFile(domain string, zoneFile string) string {
+	return testAccCheckLinodeDomainConfigBasic(domain) + fmt.Sprintf(`
+resource "linode_domain_zone" "foobar" {
+	domain_id = "${linode_domain.foobar.id}"
+	zone_file = <<EOT
+%s
+EOT
+}`, zoneFile)
+}
+
+func testAccCheckLinodeDomainZoneConfigRecords(domain string) string {
+	return testAccCheckLinodeDomainConfigBasic(domain) + `
+resource "linode_domain_zone" "foobar" {
+	domain_id = "${linode_domain.foobar.id}"
+
+	record {
+		name = "www"
+		type = "A"
+		target = "192.0.2.1"
+	}
+
+	record {
+		type = "MX"
+		target = "mail.example."
+		priority = 10
+	}
+}`
+}
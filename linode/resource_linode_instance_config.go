@@ -0,0 +1,174 @@
+package linode
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/chiefy/linodego"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceLinodeInstanceConfig() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceLinodeInstanceConfigCreate,
+		Read:   resourceLinodeInstanceConfigRead,
+		Update: resourceLinodeInstanceConfigUpdate,
+		Delete: resourceLinodeInstanceConfigDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceLinodeInstanceConfigImport,
+		},
+		Schema: linodeConfigSchema(map[string]*schema.Schema{
+			"linode_id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Description: "The ID of the Linode instance to create the config on.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"boot": &schema.Schema{
+				Type:        schema.TypeBool,
+				Description: "Whether the Linode instance boots with this config. A running instance is rebooted into it when it is created or its devices change.",
+				Optional:    true,
+				Default:     false,
+			},
+		}),
+	}
+}
+
+func resourceLinodeInstanceConfigRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(linodego.Client)
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("Failed to parse Linode Instance Config ID %s as int because %s", d.Id(), err)
+	}
+	linodeID := d.Get("linode_id").(int)
+
+	config, err := client.GetInstanceConfig(linodeID, int(id))
+	if err != nil {
+		if lerr, ok := err.(*linodego.Error); ok && lerr.Code == 404 {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Failed to find the specified Linode Instance Config because %s", err)
+	}
+
+	disks, err := client.ListInstanceDisks(linodeID, nil)
+	if err != nil {
+		return fmt.Errorf("Failed to get the disks for the Linode instance %d because %s", linodeID, err)
+	}
+
+	for key, value := range flattenLinodeConfig(config, disks, d.Get("devices").([]interface{})) {
+		if key != "id" {
+			d.Set(key, value)
+		}
+	}
+
+	return nil
+}
+
+func resourceLinodeInstanceConfigCreate(d *schema.ResourceData, meta interface{}) error {
+	client, ok := meta.(linodego.Client)
+	if !ok {
+		return fmt.Errorf("Invalid Client when creating Linode Instance Config")
+	}
+	linodeID := d.Get("linode_id").(int)
+
+	createOpts, err := expandLinodeInstanceConfig(&client, d)
+	if err != nil {
+		return err
+	}
+	config, err := client.CreateInstanceConfig(linodeID, createOpts)
+	if err != nil {
+		return fmt.Errorf("Failed to create a Linode Instance Config for Linode instance %d because %s", linodeID, err)
+	}
+	d.SetId(fmt.Sprintf("%d", config.ID))
+
+	if d.Get("boot").(bool) {
+		if err = rebootInstanceIfRunning(&client, linodeID, config); err != nil {
+			return err
+		}
+	}
+
+	return resourceLinodeInstanceConfigRead(d, meta)
+}
+
+func resourceLinodeInstanceConfigUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(linodego.Client)
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("Failed to parse Linode Instance Config ID %s as int because %s", d.Id(), err)
+	}
+	linodeID := d.Get("linode_id").(int)
+
+	updateOpts, err := expandLinodeInstanceConfig(&client, d)
+	if err != nil {
+		return err
+	}
+	current, err := client.GetInstanceConfig(linodeID, int(id))
+	if err != nil {
+		return fmt.Errorf("Failed to get Linode Instance Config %d because %s", id, err)
+	}
+	keepLinodeConfigVolumes(updateOpts.Devices, current.Devices)
+
+	config, err := client.UpdateInstanceConfig(linodeID, int(id), linodego.InstanceConfigUpdateOptions(updateOpts))
+	if err != nil {
+		return fmt.Errorf("Failed to update Linode Instance Config %d because %s", id, err)
+	}
+
+	// New devices are only attached when the instance boots
+	if d.Get("boot").(bool) && (d.HasChange("devices") || d.HasChange("boot")) {
+		if err = rebootInstanceIfRunning(&client, linodeID, config); err != nil {
+			return err
+		}
+	}
+
+	return resourceLinodeInstanceConfigRead(d, meta)
+}
+
+func resourceLinodeInstanceConfigDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(linodego.Client)
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("Failed to parse Linode Instance Config ID %s as int", d.Id())
+	}
+	linodeID := d.Get("linode_id").(int)
+
+	err = client.DeleteInstanceConfig(linodeID, int(id))
+	if err != nil {
+		if lerr, ok := err.(*linodego.Error); ok && lerr.Code == 404 {
+			return nil
+		}
+		return fmt.Errorf("Failed to delete Linode Instance Config %d because %s", id, err)
+	}
+	return nil
+}
+
+// resourceLinodeInstanceConfigImport imports a config of a Linode instance from a linodeID,configID ID
+func resourceLinodeInstanceConfigImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	ids, err := parseCompositeID(d.Id(), 2)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to import Linode Instance Config %s, expected linodeID,configID: %s", d.Id(), err)
+	}
+
+	d.SetId(strconv.Itoa(ids[1]))
+	d.Set("linode_id", ids[0])
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// expandLinodeInstanceConfig builds the options of a config, looking up disks named by label on the Linode instance
+func expandLinodeInstanceConfig(client *linodego.Client, d *schema.ResourceData) (linodego.InstanceConfigCreateOptions, error) {
+	if err := validateLinodeConfigDevices(d.Get("devices").([]interface{})); err != nil {
+		return linodego.InstanceConfigCreateOptions{}, fmt.Errorf("Invalid devices for config %s: %s", d.Get("label"), err)
+	}
+
+	diskIDs, err := linodeDiskIDs(client, d.Get("linode_id").(int))
+	if err != nil {
+		return linodego.InstanceConfigCreateOptions{}, err
+	}
+
+	config := make(map[string]interface{})
+	for key := range linodeConfigSchema(nil) {
+		config[key] = d.Get(key)
+	}
+	return expandLinodeConfigOptions(config, diskIDs)
+}
@@ -0,0 +1,134 @@
+package linode
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/chiefy/linodego"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccLinodeInstanceConfigBasic(t *testing.T) {
+	t.Parallel()
+
+	resName := "linode_instance_config.foobar"
+	var name = fmt.Sprintf("tf_test_%s", acctest.RandString(10))
+	publicKeyMaterial, _, err := acctest.RandSSHKeyPair("linode@ssh-acceptance-test")
+	if err != nil {
+		t.Fatalf("Cannot generate test SSH key pair: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLinodeInstanceConfigDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckLinodeInstanceConfigConfigBasic(name, publicKeyMaterial, "sdb"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeInstanceConfigExists,
+					resource.TestCheckResourceAttrPair(resName, "linode_id", "linode_linode.foobar", "id"),
+					resource.TestCheckResourceAttr(resName, "label", "with-data"),
+					resource.TestCheckResourceAttr(resName, "kernel", "linode/latest-64bit"),
+					resource.TestCheckResourceAttr(resName, "run_level", "default"),
+					resource.TestCheckResourceAttr(resName, "devices.0.sda.0.disk_label", "root"),
+					resource.TestCheckResourceAttrPair(resName, "devices.0.sdb.0.disk_id", "linode_instance_disk.foobar", "id"),
+					resource.TestCheckResourceAttr(resName, "helpers.0.network", "true"),
+					// The disk of linode_instance_disk is not taken over by the Linode
+					resource.TestCheckResourceAttr("linode_linode.foobar", "disk.#", "1"),
+				),
+			},
+			// Moving the data disk reboots the Linode into the config
+			resource.TestStep{
+				Config: testAccCheckLinodeInstanceConfigConfigBasic(name, publicKeyMaterial, "sdc"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeInstanceConfigExists,
+					resource.TestCheckResourceAttr(resName, "devices.0.sdb.#", "0"),
+					resource.TestCheckResourceAttrPair(resName, "devices.0.sdc.0.disk_id", "linode_instance_disk.foobar", "id"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:            resName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateIdFunc:       testAccLinodeInstanceChildImportID(resName),
+				ImportStateVerifyIgnore: []string{"boot", "devices"},
+			},
+		},
+	})
+}
+
+func testAccCheckLinodeInstanceConfigExists(s *terraform.State) error {
+	client := testAccProvider.Meta().(linodego.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "linode_instance_config" {
+			continue
+		}
+
+		id, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("Failed parsing %v to int", rs.Primary.ID)
+		}
+		linodeID, err := strconv.Atoi(rs.Primary.Attributes["linode_id"])
+		if err != nil {
+			return fmt.Errorf("Failed parsing %v to int", rs.Primary.Attributes["linode_id"])
+		}
+
+		if _, err = client.GetInstanceConfig(linodeID, id); err != nil {
+			return fmt.Errorf("Error retrieving state of Config %d of Linode %d: %s", id, linodeID, err)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckLinodeInstanceConfigDestroy(s *terraform.State) error {
+	client, ok := testAccProvider.Meta().(linodego.Client)
+	if !ok {
+		return fmt.Errorf("Failed to get Linode client")
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "linode_instance_config" {
+			continue
+		}
+
+		id, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("Failed parsing %v to int", rs.Primary.ID)
+		}
+		linodeID, err := strconv.Atoi(rs.Primary.Attributes["linode_id"])
+		if err != nil {
+			return fmt.Errorf("Failed parsing %v to int", rs.Primary.Attributes["linode_id"])
+		}
+
+		_, err = client.GetInstanceConfig(linodeID, id)
+		if err == nil {
+			return fmt.Errorf("Config %d of Linode %d still exists", id, linodeID)
+		}
+
+		if apiErr, ok := err.(*linodego.Error); ok && apiErr.Code != 404 {
+			return fmt.Errorf("Failed to request Config %d of Linode %d", id, linodeID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckLinodeInstanceConfigConfigBasic(name string, pubkey string, device string) string {
+	return testAccCheckLinodeInstanceDiskConfigBasic(name, pubkey, "data", 1024) + fmt.Sprintf(`
+
+resource "linode_instance_config" "foobar" {
+	linode_id = "${linode_linode.foobar.id}"
+	label = "with-data"
+	boot = true
+
+	devices {
+		sda { disk_label = "root" }
+		%s { disk_id = "${linode_instance_disk.foobar.id}" }
+	}
+}`, device)
+}
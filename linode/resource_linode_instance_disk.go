@@ -0,0 +1,198 @@
+package linode
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/chiefy/linodego"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceLinodeInstanceDisk() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceLinodeInstanceDiskCreate,
+		Read:   resourceLinodeInstanceDiskRead,
+		Update: resourceLinodeInstanceDiskUpdate,
+		Delete: resourceLinodeInstanceDiskDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceLinodeInstanceDiskImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"linode_id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Description: "The ID of the Linode instance to create the disk on.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"label": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "The label of the disk, unique among the disks of the Linode instance.",
+				Required:    true,
+			},
+			"size": &schema.Schema{
+				Type:        schema.TypeInt,
+				Description: "The size of the disk in MB. The Linode instance is shut down while the disk is resized.",
+				Required:    true,
+			},
+			"filesystem": &schema.Schema{
+				Type:         schema.TypeString,
+				Description:  "The filesystem of the disk. (ext3, ext4, swap, raw, initrd)",
+				Optional:     true,
+				Default:      "ext4",
+				ForceNew:     true,
+				ValidateFunc: validateOneOf("ext3", "ext4", "swap", "raw", "initrd"),
+			},
+			"image": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "The image to deploy to the disk.",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"root_password": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "The password for the root user of the image deployed to the disk.",
+				Optional:    true,
+				ForceNew:    true,
+				Sensitive:   true,
+				StateFunc:   rootPasswordState,
+			},
+			"ssh_key": &schema.Schema{
+				Type:        schema.TypeList,
+				Description: "The public keys to add to the root user of the image deployed to the disk.",
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"status": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "The status of the disk. (ready, not ready, deleting)",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceLinodeInstanceDiskRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(linodego.Client)
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("Failed to parse Linode Instance Disk ID %s as int because %s", d.Id(), err)
+	}
+	linodeID := d.Get("linode_id").(int)
+
+	disk, err := client.GetInstanceDisk(linodeID, int(id))
+	if err != nil {
+		if lerr, ok := err.(*linodego.Error); ok && lerr.Code == 404 {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Failed to find the specified Linode Instance Disk because %s", err)
+	}
+
+	d.Set("label", disk.Label)
+	d.Set("size", disk.Size)
+	d.Set("filesystem", disk.Filesystem)
+	d.Set("status", disk.Status)
+
+	return nil
+}
+
+func resourceLinodeInstanceDiskCreate(d *schema.ResourceData, meta interface{}) error {
+	client, ok := meta.(linodego.Client)
+	if !ok {
+		return fmt.Errorf("Invalid Client when creating Linode Instance Disk")
+	}
+	linodeID := d.Get("linode_id").(int)
+
+	if d.Get("image").(string) != "" && d.Get("root_password").(string) == "" {
+		return fmt.Errorf("root_password is required to deploy image %s", d.Get("image"))
+	}
+
+	createOpts := linodego.InstanceDiskCreateOptions{
+		Label:          d.Get("label").(string),
+		Size:           d.Get("size").(int),
+		Filesystem:     d.Get("filesystem").(string),
+		Image:          d.Get("image").(string),
+		RootPass:       d.Get("root_password").(string),
+		AuthorizedKeys: expandStringList(d.Get("ssh_key").([]interface{})),
+	}
+	disk, err := client.CreateInstanceDisk(linodeID, createOpts)
+	if err != nil {
+		return fmt.Errorf("Failed to create a Linode Instance Disk for Linode instance %d because %s", linodeID, err)
+	}
+	d.SetId(fmt.Sprintf("%d", disk.ID))
+
+	if err = waitForDiskStatus(&client, linodeID, disk.ID, DiskReady, WaitTimeout); err != nil {
+		return fmt.Errorf("Failed waiting for Linode Instance Disk %d to be created because %s", disk.ID, err)
+	}
+
+	return resourceLinodeInstanceDiskRead(d, meta)
+}
+
+func resourceLinodeInstanceDiskUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(linodego.Client)
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("Failed to parse Linode Instance Disk ID %s as int because %s", d.Id(), err)
+	}
+	linodeID := d.Get("linode_id").(int)
+
+	if d.HasChange("label") {
+		if _, err = client.RenameInstanceDisk(linodeID, int(id), d.Get("label").(string)); err != nil {
+			return fmt.Errorf("Failed to rename Linode Instance Disk %d because %s", id, err)
+		}
+	}
+
+	if d.HasChange("size") {
+		wasRunning, err := shutdownInstanceIfRunning(&client, linodeID)
+		if err != nil {
+			return err
+		}
+		if _, err = client.ResizeInstanceDisk(linodeID, int(id), d.Get("size").(int)); err != nil {
+			return fmt.Errorf("Failed to resize Linode Instance Disk %d because %s", id, err)
+		}
+		if err = waitForDiskStatus(&client, linodeID, int(id), DiskReady, WaitTimeout); err != nil {
+			return fmt.Errorf("Failed waiting for Linode Instance Disk %d to be resized because %s", id, err)
+		}
+		if wasRunning {
+			if err = bootInstance(&client, linodeID, 0); err != nil {
+				return err
+			}
+		}
+	}
+
+	return resourceLinodeInstanceDiskRead(d, meta)
+}
+
+func resourceLinodeInstanceDiskDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(linodego.Client)
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("Failed to parse Linode Instance Disk ID %s as int", d.Id())
+	}
+	linodeID := d.Get("linode_id").(int)
+
+	if err = client.DeleteInstanceDisk(linodeID, int(id)); err != nil {
+		if lerr, ok := err.(*linodego.Error); ok && lerr.Code == 404 {
+			return nil
+		}
+		return fmt.Errorf("Failed to delete Linode Instance Disk %d because %s", id, err)
+	}
+	if err = waitForDiskDeleted(&client, linodeID, int(id), WaitTimeout); err != nil {
+		return fmt.Errorf("Failed waiting for Linode Instance Disk %d to be deleted because %s", id, err)
+	}
+	return nil
+}
+
+// resourceLinodeInstanceDiskImport imports a disk of a Linode instance from a linodeID,diskID ID
+func resourceLinodeInstanceDiskImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	ids, err := parseCompositeID(d.Id(), 2)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to import Linode Instance Disk %s, expected linodeID,diskID: %s", d.Id(), err)
+	}
+
+	d.SetId(strconv.Itoa(ids[1]))
+	d.Set("linode_id", ids[0])
+
+	return []*schema.ResourceData{d}, nil
+}
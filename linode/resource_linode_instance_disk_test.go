@@ -0,0 +1,152 @@
+package linode
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/chiefy/linodego"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccLinodeInstanceDiskBasic(t *testing.T) {
+	t.Parallel()
+
+	resName := "linode_instance_disk.foobar"
+	var name = fmt.Sprintf("tf_test_%s", acctest.RandString(10))
+	var diskID string
+	publicKeyMaterial, _, err := acctest.RandSSHKeyPair("linode@ssh-acceptance-test")
+	if err != nil {
+		t.Fatalf("Cannot generate test SSH key pair: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLinodeInstanceDiskDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckLinodeInstanceDiskConfigBasic(name, publicKeyMaterial, "data", 1024),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeInstanceDiskExists,
+					resource.TestCheckResourceAttrPair(resName, "linode_id", "linode_linode.foobar", "id"),
+					resource.TestCheckResourceAttr(resName, "label", "data"),
+					resource.TestCheckResourceAttr(resName, "size", "1024"),
+					resource.TestCheckResourceAttr(resName, "filesystem", "ext4"),
+					resource.TestCheckResourceAttr(resName, "status", "ready"),
+					testAccCheckResourceAttrCapture(resName, "id", &diskID),
+				),
+			},
+			// Renaming and resizing the disk must keep it
+			resource.TestStep{
+				Config: testAccCheckLinodeInstanceDiskConfigBasic(name, publicKeyMaterial, "db", 2048),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeInstanceDiskExists,
+					resource.TestCheckResourceAttr(resName, "label", "db"),
+					resource.TestCheckResourceAttr(resName, "size", "2048"),
+					resource.TestCheckResourceAttrPtr(resName, "id", &diskID),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      resName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccLinodeInstanceChildImportID(resName),
+			},
+		},
+	})
+}
+
+// testAccLinodeInstanceChildImportID returns the linodeID,ID import ID of a resource belonging to a Linode instance
+func testAccLinodeInstanceChildImportID(name string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return "", fmt.Errorf("Resource not found: %s", name)
+		}
+		return fmt.Sprintf("%s,%s", rs.Primary.Attributes["linode_id"], rs.Primary.ID), nil
+	}
+}
+
+func testAccCheckLinodeInstanceDiskExists(s *terraform.State) error {
+	client := testAccProvider.Meta().(linodego.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "linode_instance_disk" {
+			continue
+		}
+
+		id, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("Failed parsing %v to int", rs.Primary.ID)
+		}
+		linodeID, err := strconv.Atoi(rs.Primary.Attributes["linode_id"])
+		if err != nil {
+			return fmt.Errorf("Failed parsing %v to int", rs.Primary.Attributes["linode_id"])
+		}
+
+		if _, err = client.GetInstanceDisk(linodeID, id); err != nil {
+			return fmt.Errorf("Error retrieving state of Disk %d of Linode %d: %s", id, linodeID, err)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckLinodeInstanceDiskDestroy(s *terraform.State) error {
+	client, ok := testAccProvider.Meta().(linodego.Client)
+	if !ok {
+		return fmt.Errorf("Failed to get Linode client")
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "linode_instance_disk" {
+			continue
+		}
+
+		id, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("Failed parsing %v to int", rs.Primary.ID)
+		}
+		linodeID, err := strconv.Atoi(rs.Primary.Attributes["linode_id"])
+		if err != nil {
+			return fmt.Errorf("Failed parsing %v to int", rs.Primary.Attributes["linode_id"])
+		}
+
+		_, err = client.GetInstanceDisk(linodeID, id)
+		if err == nil {
+			return fmt.Errorf("Disk %d of Linode %d still exists", id, linodeID)
+		}
+
+		if apiErr, ok := err.(*linodego.Error); ok && apiErr.Code != 404 {
+			return fmt.Errorf("Failed to request Disk %d of Linode %d", id, linodeID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckLinodeInstanceDiskConfigBasic(name string, pubkey string, label string, size int) string {
+	return fmt.Sprintf(`
+resource "linode_linode" "foobar" {
+	name = "%s"
+	type = "g6-nanode-1"
+	region = "us-east"
+	kernel = "linode/latest-64bit"
+
+	disk {
+		label = "root"
+		size = 4096
+		image = "linode/ubuntu18.04"
+		root_password = "terraform-test"
+		ssh_key = ["%s"]
+	}
+}
+
+resource "linode_instance_disk" "foobar" {
+	linode_id = "${linode_linode.foobar.id}"
+	label = "%s"
+	size = %d
+}`, name, pubkey, label, size)
+}
@@ -0,0 +1,131 @@
+package linode
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/chiefy/linodego"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceLinodeInstanceSnapshot() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceLinodeInstanceSnapshotCreate,
+		Read:   resourceLinodeInstanceSnapshotRead,
+		Delete: resourceLinodeInstanceSnapshotDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceLinodeInstanceSnapshotImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"linode_id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Description: "The ID of the Linode instance to snapshot. The Backup service must be enabled for it.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"label": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "The label of the snapshot.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"status": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "The status of the snapshot. (pending, running, needsPostProcessing, successful, failed, userAborted)",
+				Computed:    true,
+			},
+			"created": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"finished": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"configs": &schema.Schema{
+				Type:        schema.TypeList,
+				Description: "The labels of the configs saved in the snapshot.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Computed:    true,
+			},
+			"disks": &schema.Schema{
+				Type:        schema.TypeList,
+				Description: "The disks saved in the snapshot.",
+				Computed:    true,
+				Elem:        linodeSnapshotDiskSchema(),
+			},
+		},
+	}
+}
+
+func resourceLinodeInstanceSnapshotRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(linodego.Client)
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("Failed to parse Linode Instance Snapshot ID %s as int because %s", d.Id(), err)
+	}
+	linodeID := d.Get("linode_id").(int)
+
+	// A snapshot is gone once a newer snapshot of the Linode instance replaces it
+	snapshot, err := client.GetInstanceSnapshot(linodeID, int(id))
+	if err != nil {
+		if lerr, ok := err.(*linodego.Error); ok && lerr.Code == 404 {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Failed to find the specified Linode Instance Snapshot because %s", err)
+	}
+
+	for key, value := range flattenLinodeSnapshot(snapshot) {
+		if key != "id" && key != "type" {
+			d.Set(key, value)
+		}
+	}
+
+	return nil
+}
+
+func resourceLinodeInstanceSnapshotCreate(d *schema.ResourceData, meta interface{}) error {
+	client, ok := meta.(linodego.Client)
+	if !ok {
+		return fmt.Errorf("Invalid Client when creating Linode Instance Snapshot")
+	}
+	linodeID := d.Get("linode_id").(int)
+
+	minStart := time.Now()
+	snapshot, err := client.CreateInstanceSnapshot(linodeID, d.Get("label").(string))
+	if err != nil {
+		return fmt.Errorf("Failed to create a Linode Instance Snapshot of Linode instance %d because %s", linodeID, err)
+	}
+	d.SetId(fmt.Sprintf("%d", snapshot.ID))
+
+	if snapshot.Created != nil {
+		minStart = *snapshot.Created
+	}
+	if _, err = client.WaitForEventFinished(linodeID, linodego.EntityLinode, linodego.ActionBackupsCreate, minStart, WaitTimeout); err != nil {
+		return fmt.Errorf("Failed waiting for Linode Instance Snapshot %d to be taken because %s", snapshot.ID, err)
+	}
+
+	return resourceLinodeInstanceSnapshotRead(d, meta)
+}
+
+// resourceLinodeInstanceSnapshotDelete only forgets the snapshot, since the API keeps the latest snapshot of a Linode
+// instance until it is replaced by another one or the Backup service is cancelled
+func resourceLinodeInstanceSnapshotDelete(d *schema.ResourceData, meta interface{}) error {
+	d.SetId("")
+	return nil
+}
+
+// resourceLinodeInstanceSnapshotImport imports a snapshot of a Linode instance from a linodeID,snapshotID ID
+func resourceLinodeInstanceSnapshotImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	ids, err := parseCompositeID(d.Id(), 2)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to import Linode Instance Snapshot %s, expected linodeID,snapshotID: %s", d.Id(), err)
+	}
+
+	d.SetId(strconv.Itoa(ids[1]))
+	d.Set("linode_id", ids[0])
+
+	return []*schema.ResourceData{d}, nil
+}
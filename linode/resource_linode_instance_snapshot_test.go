@@ -0,0 +1,93 @@
+package linode
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/chiefy/linodego"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccLinodeInstanceSnapshotBasic(t *testing.T) {
+	t.Parallel()
+
+	resName := "linode_instance_snapshot.foobar"
+	var name = fmt.Sprintf("tf_test_%s", acctest.RandString(10))
+	publicKeyMaterial, _, err := acctest.RandSSHKeyPair("linode@ssh-acceptance-test")
+	if err != nil {
+		t.Fatalf("Cannot generate test SSH key pair: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLinodeLinodeDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckLinodeInstanceSnapshotConfigBasic(name, publicKeyMaterial),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeInstanceSnapshotExists,
+					resource.TestCheckResourceAttrPair(resName, "linode_id", "linode_linode.foobar", "id"),
+					resource.TestCheckResourceAttr(resName, "label", "tf-test-snapshot"),
+					resource.TestCheckResourceAttr(resName, "status", "successful"),
+					resource.TestCheckResourceAttr(resName, "disks.#", "2"),
+					resource.TestCheckResourceAttr(resName, "configs.#", "1"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      resName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccLinodeInstanceChildImportID(resName),
+			},
+		},
+	})
+}
+
+func testAccCheckLinodeInstanceSnapshotExists(s *terraform.State) error {
+	client := testAccProvider.Meta().(linodego.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "linode_instance_snapshot" {
+			continue
+		}
+
+		id, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("Failed parsing %v to int", rs.Primary.ID)
+		}
+		linodeID, err := strconv.Atoi(rs.Primary.Attributes["linode_id"])
+		if err != nil {
+			return fmt.Errorf("Failed parsing %v to int", rs.Primary.Attributes["linode_id"])
+		}
+
+		if _, err = client.GetInstanceSnapshot(linodeID, id); err != nil {
+			return fmt.Errorf("Error retrieving state of Snapshot %d of Linode %d: %s", id, linodeID, err)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckLinodeInstanceSnapshotConfigBasic(name string, pubkey string) string {
+	return fmt.Sprintf(`
+resource "linode_linode" "foobar" {
+	name = "%s"
+	type = "g6-nanode-1"
+	image = "linode/ubuntu18.04"
+	region = "us-east"
+	kernel = "linode/latest-64bit"
+	root_password = "terraform-test"
+	swap_size = 256
+	ssh_key = "%s"
+	backups_enabled = true
+}
+
+resource "linode_instance_snapshot" "foobar" {
+	linode_id = "${linode_linode.foobar.id}"
+	label = "tf-test-snapshot"
+}`, name, pubkey)
+}
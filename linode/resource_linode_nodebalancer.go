@@ -0,0 +1,152 @@
+package linode
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/chiefy/linodego"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceLinodeNodeBalancer() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceLinodeNodeBalancerCreate,
+		Read:   resourceLinodeNodeBalancerRead,
+		Update: resourceLinodeNodeBalancerUpdate,
+		Delete: resourceLinodeNodeBalancerDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"label": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "The label of the Linode NodeBalancer.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"region": &schema.Schema{
+				Type:         schema.TypeString,
+				Description:  "The region where this NodeBalancer will be deployed.",
+				Required:     true,
+				ForceNew:     true,
+				InputDefault: "us-east",
+			},
+			"client_conn_throttle": &schema.Schema{
+				Type:         schema.TypeInt,
+				Description:  "Throttle connections per second (0-20). Set to 0 (zero) to disable throttling.",
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validateNodeBalancerClientConnThrottle,
+			},
+			"hostname": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "This NodeBalancer's hostname, ending with .nodebalancer.linode.com",
+				Computed:    true,
+			},
+			"ipv4": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "The Public IPv4 Address of this NodeBalancer",
+				Computed:    true,
+			},
+			"ipv6": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "The Public IPv6 Address of this NodeBalancer",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceLinodeNodeBalancerRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(linodego.Client)
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("Failed to parse Linode NodeBalancer ID %s as int because %s", d.Id(), err)
+	}
+
+	nodebalancer, err := client.GetNodeBalancer(int(id))
+	if err != nil {
+		if lerr, ok := err.(*linodego.Error); ok && lerr.Code == 404 {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Failed to find the specified Linode NodeBalancer because %s", err)
+	}
+
+	d.Set("label", nodebalancer.Label)
+	d.Set("region", nodebalancer.Region)
+	d.Set("client_conn_throttle", nodebalancer.ClientConnThrottle)
+	d.Set("hostname", nodebalancer.Hostname)
+	d.Set("ipv4", nodebalancer.IPv4)
+	d.Set("ipv6", nodebalancer.IPv6)
+
+	return nil
+}
+
+func resourceLinodeNodeBalancerCreate(d *schema.ResourceData, meta interface{}) error {
+	client, ok := meta.(linodego.Client)
+	if !ok {
+		return fmt.Errorf("Invalid Client when creating Linode NodeBalancer")
+	}
+
+	createOpts := linodego.NodeBalancerCreateOptions{
+		Label:              d.Get("label").(string),
+		Region:             d.Get("region").(string),
+		ClientConnThrottle: d.Get("client_conn_throttle").(int),
+	}
+	nodebalancer, err := client.CreateNodeBalancer(&createOpts)
+	if err != nil {
+		return fmt.Errorf("Failed to create a Linode NodeBalancer in region %s because %s", d.Get("region"), err)
+	}
+	d.SetId(fmt.Sprintf("%d", nodebalancer.ID))
+
+	return resourceLinodeNodeBalancerRead(d, meta)
+}
+
+func resourceLinodeNodeBalancerUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(linodego.Client)
+
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("Failed to parse Linode NodeBalancer ID %s as int because %s", d.Id(), err)
+	}
+
+	nodebalancer, err := client.GetNodeBalancer(int(id))
+	if err != nil {
+		return fmt.Errorf("Failed to fetch data about the current Linode NodeBalancer because %s", err)
+	}
+
+	if d.HasChange("label") || d.HasChange("client_conn_throttle") {
+		updateOpts := nodebalancer.GetUpdateOptions()
+		updateOpts.Label = d.Get("label").(string)
+		updateOpts.ClientConnThrottle = d.Get("client_conn_throttle").(int)
+
+		if _, err = client.UpdateNodeBalancer(nodebalancer.ID, updateOpts); err != nil {
+			return fmt.Errorf("Failed to update Linode NodeBalancer %d because %s", nodebalancer.ID, err)
+		}
+	}
+
+	return resourceLinodeNodeBalancerRead(d, meta)
+}
+
+func resourceLinodeNodeBalancerDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(linodego.Client)
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("Failed to parse Linode NodeBalancer ID %s as int", d.Id())
+	}
+	err = client.DeleteNodeBalancer(int(id))
+	if err != nil {
+		return fmt.Errorf("Failed to delete Linode NodeBalancer %d because %s", id, err)
+	}
+	return nil
+}
+
+// validateNodeBalancerClientConnThrottle checks that a connection throttle is within the range the API accepts
+func validateNodeBalancerClientConnThrottle(v interface{}, k string) (ws []string, es []error) {
+	throttle := v.(int)
+	if throttle < 0 || throttle > 20 {
+		es = append(es, fmt.Errorf("%q must be between 0 and 20, got %d", k, throttle))
+	}
+	return
+}
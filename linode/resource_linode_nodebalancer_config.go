@@ -0,0 +1,318 @@
+package linode
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/chiefy/linodego"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceLinodeNodeBalancerConfig() *schema.Resource {
+	return &schema.Resource{
+		Create:        resourceLinodeNodeBalancerConfigCreate,
+		Read:          resourceLinodeNodeBalancerConfigRead,
+		Update:        resourceLinodeNodeBalancerConfigUpdate,
+		Delete:        resourceLinodeNodeBalancerConfigDelete,
+		CustomizeDiff: resourceLinodeNodeBalancerConfigCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			State: resourceLinodeNodeBalancerConfigImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"nodebalancer_id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Description: "The ID of the NodeBalancer to access.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"port": &schema.Schema{
+				Type:         schema.TypeInt,
+				Description:  "The TCP port this Config is for. These values must be unique across configs on a single NodeBalancer (you can't have two configs for port 80, for example). While some ports imply some protocols, no enforcement is done and you may configure your NodeBalancer however is useful to you. For example, while port 443 is generally used for HTTPS, you do not need SSL configured to have a NodeBalancer listening on port 443.",
+				Optional:     true,
+				Default:      80,
+				ValidateFunc: validatePort,
+			},
+			"protocol": &schema.Schema{
+				Type:         schema.TypeString,
+				Description:  "The protocol this port is configured to serve. If this is set to https you must include an ssl_cert and an ssl_key. (http, https, tcp)",
+				Optional:     true,
+				Default:      "http",
+				ValidateFunc: validateOneOf("http", "https", "tcp"),
+			},
+			"algorithm": &schema.Schema{
+				Type:         schema.TypeString,
+				Description:  "What algorithm this NodeBalancer should use for routing traffic to backends (roundrobin, leastconn, source)",
+				Optional:     true,
+				Default:      "roundrobin",
+				ValidateFunc: validateOneOf("roundrobin", "leastconn", "source"),
+			},
+			"stickiness": &schema.Schema{
+				Type:         schema.TypeString,
+				Description:  "Controls how session stickiness is handled on this port. (none, table, http_cookie)",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateOneOf("none", "table", "http_cookie"),
+			},
+			"check": &schema.Schema{
+				Type:         schema.TypeString,
+				Description:  "The type of check to perform against backends to ensure they are serving requests. This is used to determine if backends are up or down. If none no check is performed. connection requires only a connection to the backend to succeed. http and http_body rely on the backend serving HTTP, and that the response returned matches what is expected.",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateOneOf("none", "connection", "http", "http_body"),
+			},
+			"check_interval": &schema.Schema{
+				Type:        schema.TypeInt,
+				Description: "How often, in seconds, to check that backends are up and serving requests.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"check_timeout": &schema.Schema{
+				Type:        schema.TypeInt,
+				Description: "How long, in seconds, to wait for a check attempt before considering it failed. (1-30)",
+				Optional:    true,
+				Computed:    true,
+			},
+			"check_attempts": &schema.Schema{
+				Type:        schema.TypeInt,
+				Description: "How many times to attempt a check before considering a backend to be down. (1-30)",
+				Optional:    true,
+				Computed:    true,
+			},
+			"check_path": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "The URL path to check on each backend. If the backend does not respond to this request it is considered to be down.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"check_body": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "This value must be present in the response body of the check in order for it to pass. If this value is not present in the response body of a check request, the backend is considered to be down",
+				Optional:    true,
+				Computed:    true,
+			},
+			"check_passive": &schema.Schema{
+				Type:        schema.TypeBool,
+				Description: "If true, any response from this backend with a 5xx status code will be enough for it to be considered unhealthy and taken out of rotation.",
+				Optional:    true,
+				Default:     true,
+			},
+			"cipher_suite": &schema.Schema{
+				Type:         schema.TypeString,
+				Description:  "What ciphers to use for SSL connections served by this NodeBalancer. `legacy` is considered insecure and should only be used if necessary.",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateOneOf("recommended", "legacy"),
+			},
+			"ssl_cert": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "The certificate this port is serving. This is not returned. If set, this field will come back as `<REDACTED>`. Please use the ssl_commonname and ssl_fingerprint to identify the certificate.",
+				Optional:    true,
+			},
+			"ssl_key": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "The private key corresponding to this port's certificate. This is not returned. If set, this field will come back as `<REDACTED>`. Please use the ssl_commonname and ssl_fingerprint to identify the certificate.",
+				Optional:    true,
+				Sensitive:   true,
+				StateFunc:   sslKeyState,
+			},
+			"ssl_commonname": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "The common name for the SSL certification this port is serving, if this port is configured to use SSL.",
+				Computed:    true,
+			},
+			"ssl_fingerprint": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "The fingerprint for the SSL certification this port is serving, if this port is configured to use SSL.",
+				Computed:    true,
+			},
+			"nodes_status": &schema.Schema{
+				Type:        schema.TypeList,
+				Description: "A structure containing information about the health of the backends for this port. This information is updated periodically as checks are performed against backends.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"up": &schema.Schema{
+							Type:        schema.TypeInt,
+							Description: "The number of backends considered to be 'UP' and healthy, and that are serving requests.",
+							Computed:    true,
+						},
+						"down": &schema.Schema{
+							Type:        schema.TypeInt,
+							Description: "The number of backends considered to be 'DOWN' and unhealthy. These are not in rotation, and not serving requests.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceLinodeNodeBalancerConfigRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(linodego.Client)
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("Failed to parse Linode NodeBalancer Config ID %s as int because %s", d.Id(), err)
+	}
+	nodebalancerID := d.Get("nodebalancer_id").(int)
+
+	config, err := client.GetNodeBalancerConfig(nodebalancerID, int(id))
+	if err != nil {
+		if lerr, ok := err.(*linodego.Error); ok && lerr.Code == 404 {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Failed to find the specified Linode NodeBalancer Config because %s", err)
+	}
+
+	d.Set("port", config.Port)
+	d.Set("protocol", config.Protocol)
+	d.Set("algorithm", config.Algorithm)
+	d.Set("stickiness", config.Stickiness)
+	d.Set("check", config.Check)
+	d.Set("check_interval", config.CheckInterval)
+	d.Set("check_timeout", config.CheckTimeout)
+	d.Set("check_attempts", config.CheckAttempts)
+	d.Set("check_path", config.CheckPath)
+	d.Set("check_body", config.CheckBody)
+	d.Set("check_passive", config.CheckPassive)
+	d.Set("cipher_suite", config.CipherSuite)
+	d.Set("ssl_commonname", config.SSLCommonName)
+	d.Set("ssl_fingerprint", config.SSLFingerprint)
+
+	// ssl_cert and ssl_key are redacted by the API, so the configured values are kept in state
+
+	if config.NodesStatus != nil {
+		d.Set("nodes_status", []map[string]interface{}{{
+			"up":   config.NodesStatus.Up,
+			"down": config.NodesStatus.Down,
+		}})
+	}
+
+	return nil
+}
+
+func resourceLinodeNodeBalancerConfigCreate(d *schema.ResourceData, meta interface{}) error {
+	client, ok := meta.(linodego.Client)
+	if !ok {
+		return fmt.Errorf("Invalid Client when creating Linode NodeBalancer Config")
+	}
+	nodebalancerID := d.Get("nodebalancer_id").(int)
+
+	createOpts := linodego.NodeBalancerConfigCreateOptions{
+		Port:          d.Get("port").(int),
+		Protocol:      d.Get("protocol").(string),
+		Algorithm:     d.Get("algorithm").(string),
+		Stickiness:    d.Get("stickiness").(string),
+		Check:         d.Get("check").(string),
+		CheckInterval: d.Get("check_interval").(int),
+		CheckTimeout:  d.Get("check_timeout").(int),
+		CheckAttempts: d.Get("check_attempts").(int),
+		CheckPath:     d.Get("check_path").(string),
+		CheckBody:     d.Get("check_body").(string),
+		CheckPassive:  d.Get("check_passive").(bool),
+		CipherSuite:   d.Get("cipher_suite").(string),
+		SSLCert:       d.Get("ssl_cert").(string),
+		SSLKey:        d.Get("ssl_key").(string),
+	}
+	config, err := client.CreateNodeBalancerConfig(nodebalancerID, createOpts)
+	if err != nil {
+		return fmt.Errorf("Failed to create a Linode NodeBalancer Config for NodeBalancer %d because %s", nodebalancerID, err)
+	}
+	d.SetId(fmt.Sprintf("%d", config.ID))
+
+	return resourceLinodeNodeBalancerConfigRead(d, meta)
+}
+
+func resourceLinodeNodeBalancerConfigUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(linodego.Client)
+
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("Failed to parse Linode NodeBalancer Config ID %s as int because %s", d.Id(), err)
+	}
+	nodebalancerID := d.Get("nodebalancer_id").(int)
+
+	updateOpts := linodego.NodeBalancerConfigUpdateOptions{
+		Port:          d.Get("port").(int),
+		Protocol:      d.Get("protocol").(string),
+		Algorithm:     d.Get("algorithm").(string),
+		Stickiness:    d.Get("stickiness").(string),
+		Check:         d.Get("check").(string),
+		CheckInterval: d.Get("check_interval").(int),
+		CheckTimeout:  d.Get("check_timeout").(int),
+		CheckAttempts: d.Get("check_attempts").(int),
+		CheckPath:     d.Get("check_path").(string),
+		CheckBody:     d.Get("check_body").(string),
+		CheckPassive:  d.Get("check_passive").(bool),
+		CipherSuite:   d.Get("cipher_suite").(string),
+	}
+
+	// The certificate and key are only sent when they change. The state only holds a hash of the key, so a renewed
+	// certificate is sent alone and keeps the current key.
+	if d.HasChange("ssl_cert") {
+		updateOpts.SSLCert = d.Get("ssl_cert").(string)
+	}
+	if d.HasChange("ssl_key") {
+		updateOpts.SSLKey = d.Get("ssl_key").(string)
+	}
+
+	if _, err = client.UpdateNodeBalancerConfig(nodebalancerID, int(id), updateOpts); err != nil {
+		return fmt.Errorf("Failed to update Linode NodeBalancer Config %d because %s", id, err)
+	}
+
+	return resourceLinodeNodeBalancerConfigRead(d, meta)
+}
+
+func resourceLinodeNodeBalancerConfigDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(linodego.Client)
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("Failed to parse Linode NodeBalancer Config ID %s as int", d.Id())
+	}
+	nodebalancerID := d.Get("nodebalancer_id").(int)
+
+	err = client.DeleteNodeBalancerConfig(nodebalancerID, int(id))
+	if err != nil {
+		return fmt.Errorf("Failed to delete Linode NodeBalancer Config %d because %s", id, err)
+	}
+	return nil
+}
+
+// resourceLinodeNodeBalancerConfigImport imports a NodeBalancer Config from a nodebalancerID,configID ID
+func resourceLinodeNodeBalancerConfigImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	ids, err := parseCompositeID(d.Id(), 2)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to import Linode NodeBalancer Config %s, expected nodebalancerID,configID: %s", d.Id(), err)
+	}
+
+	d.SetId(strconv.Itoa(ids[1]))
+	d.Set("nodebalancer_id", ids[0])
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceLinodeNodeBalancerConfigCustomizeDiff requires a certificate and key when serving https
+func resourceLinodeNodeBalancerConfigCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Get("protocol").(string) != "https" {
+		return nil
+	}
+	if d.Get("ssl_cert").(string) == "" || d.Get("ssl_key").(string) == "" {
+		return fmt.Errorf("ssl_cert and ssl_key are required when protocol is https")
+	}
+	return nil
+}
+
+// sslKeyState hashes a string passed in as an interface
+func sslKeyState(val interface{}) string {
+	return hashString(val.(string))
+}
+
+// validatePort checks that a TCP port is within the range the API accepts
+func validatePort(v interface{}, k string) (ws []string, es []error) {
+	port := v.(int)
+	if port < 1 || port > 65534 {
+		es = append(es, fmt.Errorf("%q must be between 1 and 65534, got %d", k, port))
+	}
+	return
+}
@@ -0,0 +1,247 @@
+package linode
+
+import (
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/chiefy/linodego"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccLinodeNodeBalancerConfigBasic(t *testing.T) {
+	t.Parallel()
+
+	resName := "linode_nodebalancer_config.foofig"
+	var nodebalancerName = fmt.Sprintf("tf_test_%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLinodeNodeBalancerConfigDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckLinodeNodeBalancerConfigConfigBasic(nodebalancerName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeNodeBalancerConfigExists,
+					resource.TestCheckResourceAttr(resName, "port", "8080"),
+					resource.TestCheckResourceAttr(resName, "protocol", "http"),
+					resource.TestCheckResourceAttr(resName, "check", "http"),
+					resource.TestCheckResourceAttr(resName, "check_path", "/"),
+					resource.TestCheckResourceAttr(resName, "check_passive", "true"),
+					resource.TestCheckResourceAttr(resName, "nodes_status.#", "1"),
+				),
+			},
+
+			resource.TestStep{
+				ResourceName:      resName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccStateIDNodeBalancerConfig,
+			},
+		},
+	})
+}
+
+func TestAccLinodeNodeBalancerConfigSSL(t *testing.T) {
+	t.Parallel()
+
+	resName := "linode_nodebalancer_config.foofig"
+	var nodebalancerName = fmt.Sprintf("tf_test_%s", acctest.RandString(10))
+
+	cert, key, err := acctest.RandTLSCert("linode")
+	if err != nil {
+		t.Fatalf("Cannot generate test TLS certificate: %s", err)
+	}
+	cert2, key2, err := acctest.RandTLSCert("linode")
+	if err != nil {
+		t.Fatalf("Cannot generate test TLS certificate: %s", err)
+	}
+	renewedCert2, err := testAccLinodeRenewTLSCert(key2)
+	if err != nil {
+		t.Fatalf("Cannot renew test TLS certificate: %s", err)
+	}
+
+	var configID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLinodeNodeBalancerConfigDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckLinodeNodeBalancerConfigConfigSSL(nodebalancerName, cert, key),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeNodeBalancerConfigExists,
+					resource.TestCheckResourceAttr(resName, "protocol", "https"),
+					resource.TestCheckResourceAttr(resName, "cipher_suite", "recommended"),
+					resource.TestCheckResourceAttrSet(resName, "ssl_fingerprint"),
+					testAccCheckResourceAttrCapture(resName, "id", &configID),
+				),
+			},
+			// Rotating the certificate must update the config in place
+			resource.TestStep{
+				Config: testAccCheckLinodeNodeBalancerConfigConfigSSL(nodebalancerName, cert2, key2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeNodeBalancerConfigExists,
+					resource.TestCheckResourceAttrPtr(resName, "id", &configID),
+				),
+			},
+			// Renewing only the certificate must keep the key
+			resource.TestStep{
+				Config: testAccCheckLinodeNodeBalancerConfigConfigSSL(nodebalancerName, renewedCert2, key2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeNodeBalancerConfigExists,
+					resource.TestCheckResourceAttrPtr(resName, "id", &configID),
+					resource.TestCheckResourceAttrSet(resName, "ssl_fingerprint"),
+				),
+			},
+		},
+	})
+}
+
+// testAccLinodeRenewTLSCert returns a new self-signed certificate for the PEM encoded RSA key of a certificate
+// generated by acctest.RandTLSCert
+func testAccLinodeRenewTLSCert(keyPEM string) (string, error) {
+	block, _ := pem.Decode([]byte(keyPEM))
+	if block == nil {
+		return "", fmt.Errorf("Failed to decode the PEM encoded key")
+	}
+	privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		return "", err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(int64(acctest.RandInt())),
+		Subject: pkix.Name{
+			Organization: []string{"linode"},
+		},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(48 * time.Hour),
+		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+	}
+	cert, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	if err != nil {
+		return "", err
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert})), nil
+}
+
+func testAccCheckLinodeNodeBalancerConfigExists(s *terraform.State) error {
+	client := testAccProvider.Meta().(linodego.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "linode_nodebalancer_config" {
+			continue
+		}
+
+		id, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("Failed parsing %v to int", rs.Primary.ID)
+		}
+		nodebalancerID, err := strconv.Atoi(rs.Primary.Attributes["nodebalancer_id"])
+		if err != nil {
+			return fmt.Errorf("Failed parsing %v to int", rs.Primary.Attributes["nodebalancer_id"])
+		}
+
+		_, err = client.GetNodeBalancerConfig(nodebalancerID, id)
+		if err != nil {
+			return fmt.Errorf("Error retrieving state of NodeBalancer Config %d: %s", id, err)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckLinodeNodeBalancerConfigDestroy(s *terraform.State) error {
+	client, ok := testAccProvider.Meta().(linodego.Client)
+	if !ok {
+		return fmt.Errorf("Failed to get Linode client")
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "linode_nodebalancer_config" {
+			continue
+		}
+
+		id, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("Failed parsing %v to int", rs.Primary.ID)
+		}
+		nodebalancerID, err := strconv.Atoi(rs.Primary.Attributes["nodebalancer_id"])
+		if err != nil {
+			return fmt.Errorf("Failed parsing %v to int", rs.Primary.Attributes["nodebalancer_id"])
+		}
+
+		_, err = client.GetNodeBalancerConfig(nodebalancerID, id)
+
+		if err == nil {
+			return fmt.Errorf("Linode NodeBalancer Config with id %d still exists", id)
+		}
+
+		if apiErr, ok := err.(*linodego.Error); ok && apiErr.Code != 404 {
+			return fmt.Errorf("Failed to request Linode NodeBalancer Config with id %d", id)
+		}
+	}
+
+	return nil
+}
+
+func testAccStateIDNodeBalancerConfig(s *terraform.State) (string, error) {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "linode_nodebalancer_config" {
+			continue
+		}
+		return fmt.Sprintf("%s,%s", rs.Primary.Attributes["nodebalancer_id"], rs.Primary.ID), nil
+	}
+	return "", fmt.Errorf("No linode_nodebalancer_config found in state")
+}
+
+func testAccCheckResourceAttrCapture(name string, key string, value *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		*value = rs.Primary.Attributes[key]
+		return nil
+	}
+}
+
+func testAccCheckLinodeNodeBalancerConfigConfigBasic(nodebalancer string) string {
+	return testAccCheckLinodeNodeBalancerConfigBasic(nodebalancer) + `
+resource "linode_nodebalancer_config" "foofig" {
+	nodebalancer_id = "${linode_nodebalancer.foobar.id}"
+	port = 8080
+	protocol = "http"
+	check = "http"
+	check_path = "/"
+	check_passive = true
+}`
+}
+
+func testAccCheckLinodeNodeBalancerConfigConfigSSL(nodebalancer string, cert string, key string) string {
+	return testAccCheckLinodeNodeBalancerConfigBasic(nodebalancer) + fmt.Sprintf(`
+resource "linode_nodebalancer_config" "foofig" {
+	nodebalancer_id = "${linode_nodebalancer.foobar.id}"
+	port = 443
+	protocol = "https"
+	cipher_suite = "recommended"
+	ssl_cert = <<EOT
+%s
+EOT
+	ssl_key = <<EOT
+%s
+EOT
+}`, cert, key)
+}
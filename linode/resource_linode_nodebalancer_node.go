@@ -0,0 +1,190 @@
+package linode
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/chiefy/linodego"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceLinodeNodeBalancerNode() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceLinodeNodeBalancerNodeCreate,
+		Read:   resourceLinodeNodeBalancerNodeRead,
+		Update: resourceLinodeNodeBalancerNodeUpdate,
+		Delete: resourceLinodeNodeBalancerNodeDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceLinodeNodeBalancerNodeImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"nodebalancer_id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Description: "The ID of the NodeBalancer to access.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"config_id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Description: "The ID of the NodeBalancerConfig to access.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"label": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "The label for this node. This is for display purposes only.",
+				Required:    true,
+			},
+			"address": &schema.Schema{
+				Type:         schema.TypeString,
+				Description:  "The private IP Address and port (IP:PORT) where this backend can be reached. This must be a private IP address.",
+				Required:     true,
+				ValidateFunc: validateNodeBalancerNodeAddress,
+			},
+			"weight": &schema.Schema{
+				Type:         schema.TypeInt,
+				Description:  "Used when picking a backend to serve a request and is not pinned to a single backend yet. Nodes with a higher weight will receive more traffic. (1-255)",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateNodeBalancerNodeWeight,
+			},
+			"mode": &schema.Schema{
+				Type:         schema.TypeString,
+				Description:  "The mode this NodeBalancer should use when sending traffic to this backend. If set to `accept` this backend is accepting traffic. If set to `reject` this backend will not receive traffic. If set to `drain` this backend will not receive new traffic, but connections already pinned to it will continue to be routed to it. If set to `backup` this backend only receives traffic when all other backends are down.",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateOneOf("accept", "reject", "drain", "backup"),
+			},
+			"status": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "The current status of this node, based on the configured checks of its NodeBalancer Config. (unknown, UP, DOWN)",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceLinodeNodeBalancerNodeRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(linodego.Client)
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("Failed to parse Linode NodeBalancer Node ID %s as int because %s", d.Id(), err)
+	}
+	nodebalancerID, configID := d.Get("nodebalancer_id").(int), d.Get("config_id").(int)
+
+	node, err := client.GetNodeBalancerNode(nodebalancerID, configID, int(id))
+	if err != nil {
+		if lerr, ok := err.(*linodego.Error); ok && lerr.Code == 404 {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Failed to find the specified Linode NodeBalancer Node because %s", err)
+	}
+
+	d.Set("label", node.Label)
+	d.Set("address", node.Address)
+	d.Set("weight", node.Weight)
+	d.Set("mode", node.Mode)
+	d.Set("status", node.Status)
+
+	return nil
+}
+
+func resourceLinodeNodeBalancerNodeCreate(d *schema.ResourceData, meta interface{}) error {
+	client, ok := meta.(linodego.Client)
+	if !ok {
+		return fmt.Errorf("Invalid Client when creating Linode NodeBalancer Node")
+	}
+	nodebalancerID, configID := d.Get("nodebalancer_id").(int), d.Get("config_id").(int)
+
+	createOpts := linodego.NodeBalancerNodeCreateOptions{
+		Address: d.Get("address").(string),
+		Label:   d.Get("label").(string),
+		Weight:  d.Get("weight").(int),
+		Mode:    d.Get("mode").(string),
+	}
+	node, err := client.CreateNodeBalancerNode(nodebalancerID, configID, createOpts)
+	if err != nil {
+		return fmt.Errorf("Failed to create a Linode NodeBalancer Node for Config %d because %s", configID, err)
+	}
+	d.SetId(fmt.Sprintf("%d", node.ID))
+
+	return resourceLinodeNodeBalancerNodeRead(d, meta)
+}
+
+func resourceLinodeNodeBalancerNodeUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(linodego.Client)
+
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("Failed to parse Linode NodeBalancer Node ID %s as int because %s", d.Id(), err)
+	}
+	nodebalancerID, configID := d.Get("nodebalancer_id").(int), d.Get("config_id").(int)
+
+	updateOpts := linodego.NodeBalancerNodeUpdateOptions{
+		Address: d.Get("address").(string),
+		Label:   d.Get("label").(string),
+		Weight:  d.Get("weight").(int),
+		Mode:    d.Get("mode").(string),
+	}
+	if _, err = client.UpdateNodeBalancerNode(nodebalancerID, configID, int(id), updateOpts); err != nil {
+		return fmt.Errorf("Failed to update Linode NodeBalancer Node %d because %s", id, err)
+	}
+
+	return resourceLinodeNodeBalancerNodeRead(d, meta)
+}
+
+func resourceLinodeNodeBalancerNodeDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(linodego.Client)
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("Failed to parse Linode NodeBalancer Node ID %s as int", d.Id())
+	}
+	nodebalancerID, configID := d.Get("nodebalancer_id").(int), d.Get("config_id").(int)
+
+	err = client.DeleteNodeBalancerNode(nodebalancerID, configID, int(id))
+	if err != nil {
+		return fmt.Errorf("Failed to delete Linode NodeBalancer Node %d because %s", id, err)
+	}
+	return nil
+}
+
+// resourceLinodeNodeBalancerNodeImport imports a NodeBalancer Node from a nodebalancerID,configID,nodeID ID
+func resourceLinodeNodeBalancerNodeImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	ids, err := parseCompositeID(d.Id(), 3)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to import Linode NodeBalancer Node %s, expected nodebalancerID,configID,nodeID: %s", d.Id(), err)
+	}
+
+	d.SetId(strconv.Itoa(ids[2]))
+	d.Set("nodebalancer_id", ids[0])
+	d.Set("config_id", ids[1])
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// validateNodeBalancerNodeAddress checks that a node address is an IP:PORT pair
+func validateNodeBalancerNodeAddress(v interface{}, k string) (ws []string, es []error) {
+	host, port, err := net.SplitHostPort(v.(string))
+	if err != nil {
+		es = append(es, fmt.Errorf("%q must be an IP:PORT pair, got %s", k, v))
+		return
+	}
+	if net.ParseIP(host) == nil {
+		es = append(es, fmt.Errorf("%q must start with an IP address, got %s", k, host))
+	}
+	if p, err := strconv.Atoi(port); err != nil || p < 1 || p > 65535 {
+		es = append(es, fmt.Errorf("%q must end with a port between 1 and 65535, got %s", k, port))
+	}
+	return
+}
+
+// validateNodeBalancerNodeWeight checks that a node weight is within the range the API accepts
+func validateNodeBalancerNodeWeight(v interface{}, k string) (ws []string, es []error) {
+	weight := v.(int)
+	if weight < 1 || weight > 255 {
+		es = append(es, fmt.Errorf("%q must be between 1 and 255, got %d", k, weight))
+	}
+	return
+}
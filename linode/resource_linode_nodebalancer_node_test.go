@@ -0,0 +1,141 @@
+package linode
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/chiefy/linodego"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccLinodeNodeBalancerNodeBasic(t *testing.T) {
+	t.Parallel()
+
+	resName := "linode_nodebalancer_node.foonode"
+	var name = fmt.Sprintf("tf_test_%s", acctest.RandString(10))
+	publicKeyMaterial, _, err := acctest.RandSSHKeyPair("linode@ssh-acceptance-test")
+	if err != nil {
+		t.Fatalf("Cannot generate test SSH key pair: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLinodeNodeBalancerNodeDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckLinodeNodeBalancerNodeConfigBasic(name, publicKeyMaterial, "accept"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeNodeBalancerNodeExists,
+					resource.TestCheckResourceAttr(resName, "label", name),
+					resource.TestCheckResourceAttr(resName, "weight", "50"),
+					resource.TestCheckResourceAttr(resName, "mode", "accept"),
+					resource.TestCheckResourceAttrSet(resName, "status"),
+				),
+			},
+			// Drain the node without replacing it
+			resource.TestStep{
+				Config: testAccCheckLinodeNodeBalancerNodeConfigBasic(name, publicKeyMaterial, "drain"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeNodeBalancerNodeExists,
+					resource.TestCheckResourceAttr(resName, "mode", "drain"),
+				),
+			},
+
+			resource.TestStep{
+				ResourceName:      resName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccStateIDNodeBalancerNode,
+			},
+		},
+	})
+}
+
+func testAccCheckLinodeNodeBalancerNodeExists(s *terraform.State) error {
+	client := testAccProvider.Meta().(linodego.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "linode_nodebalancer_node" {
+			continue
+		}
+
+		ids, err := parseCompositeID(fmt.Sprintf("%s,%s,%s", rs.Primary.Attributes["nodebalancer_id"], rs.Primary.Attributes["config_id"], rs.Primary.ID), 3)
+		if err != nil {
+			return fmt.Errorf("Failed parsing IDs of NodeBalancer Node %s: %s", rs.Primary.ID, err)
+		}
+
+		_, err = client.GetNodeBalancerNode(ids[0], ids[1], ids[2])
+		if err != nil {
+			return fmt.Errorf("Error retrieving state of NodeBalancer Node %s: %s", rs.Primary.Attributes["label"], err)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckLinodeNodeBalancerNodeDestroy(s *terraform.State) error {
+	client, ok := testAccProvider.Meta().(linodego.Client)
+	if !ok {
+		return fmt.Errorf("Failed to get Linode client")
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "linode_nodebalancer_node" {
+			continue
+		}
+
+		ids, err := parseCompositeID(fmt.Sprintf("%s,%s,%s", rs.Primary.Attributes["nodebalancer_id"], rs.Primary.Attributes["config_id"], rs.Primary.ID), 3)
+		if err != nil {
+			return fmt.Errorf("Failed parsing IDs of NodeBalancer Node %s: %s", rs.Primary.ID, err)
+		}
+
+		_, err = client.GetNodeBalancerNode(ids[0], ids[1], ids[2])
+
+		if err == nil {
+			return fmt.Errorf("Linode NodeBalancer Node with id %d still exists", ids[2])
+		}
+
+		if apiErr, ok := err.(*linodego.Error); ok && apiErr.Code != 404 {
+			return fmt.Errorf("Failed to request Linode NodeBalancer Node with id %s", strconv.Itoa(ids[2]))
+		}
+	}
+
+	return nil
+}
+
+func testAccStateIDNodeBalancerNode(s *terraform.State) (string, error) {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "linode_nodebalancer_node" {
+			continue
+		}
+		return fmt.Sprintf("%s,%s,%s", rs.Primary.Attributes["nodebalancer_id"], rs.Primary.Attributes["config_id"], rs.Primary.ID), nil
+	}
+	return "", fmt.Errorf("No linode_nodebalancer_node found in state")
+}
+
+func testAccCheckLinodeNodeBalancerNodeConfigBasic(name string, pubkey string, mode string) string {
+	return testAccCheckLinodeNodeBalancerConfigConfigBasic(name) + fmt.Sprintf(`
+resource "linode_linode" "foobar" {
+	name = "%s"
+	type = "g6-nanode-1"
+	image = "linode/ubuntu18.04"
+	region = "us-east"
+	kernel = "linode/latest-64bit"
+	root_password = "terraform-test"
+	swap_size = 256
+	private_networking = true
+	ssh_key = "%s"
+}
+
+resource "linode_nodebalancer_node" "foonode" {
+	nodebalancer_id = "${linode_nodebalancer.foobar.id}"
+	config_id = "${linode_nodebalancer_config.foofig.id}"
+	address = "${linode_linode.foobar.private_ip_address}:80"
+	label = "%s"
+	weight = 50
+	mode = "%s"
+}`, name, pubkey, name, mode)
+}
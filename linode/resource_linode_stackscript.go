@@ -0,0 +1,382 @@
+package linode
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chiefy/linodego"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+var (
+	// stackscriptUDFTag matches the <UDF ... /> tags of a StackScript
+	stackscriptUDFTag = regexp.MustCompile(`(?i)<UDF\s((?:[^>"']|"[^"]*"|'[^']*')*)>`)
+
+	// stackscriptUDFAttr matches the name="value" and name='value' attributes of a UDF tag
+	stackscriptUDFAttr = regexp.MustCompile(`(\w+)\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+)
+
+// stackscriptUDF is a user-defined field declared in the script of a StackScript
+type stackscriptUDF struct {
+	Name     string
+	Label    string
+	Default  string
+	OneOf    string
+	ManyOf   string
+	Example  string
+	Required bool
+}
+
+func resourceLinodeStackscript() *schema.Resource {
+	return &schema.Resource{
+		Create:        resourceLinodeStackscriptCreate,
+		Read:          resourceLinodeStackscriptRead,
+		Update:        resourceLinodeStackscriptUpdate,
+		Delete:        resourceLinodeStackscriptDelete,
+		CustomizeDiff: resourceLinodeStackscriptCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"label": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "The StackScript's label is for display purposes only.",
+				Required:    true,
+			},
+			"script": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "The script to execute when provisioning a new Linode with this StackScript.",
+				Required:    true,
+			},
+			"description": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "A description for the StackScript.",
+				Optional:    true,
+			},
+			"images": &schema.Schema{
+				Type:        schema.TypeSet,
+				Description: "An array of Image IDs representing the Images that this StackScript is compatible for deploying with.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Required:    true,
+			},
+			"is_public": &schema.Schema{
+				Type:        schema.TypeBool,
+				Description: "This determines whether other users can use your StackScript. Once a StackScript is made public, it cannot be made private.",
+				Optional:    true,
+				Default:     false,
+			},
+			"rev_note": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "This field allows you to add notes for the set of revisions made to this StackScript.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"username": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "The User who created the StackScript.",
+				Computed:    true,
+			},
+			"deployments_total": &schema.Schema{
+				Type:        schema.TypeInt,
+				Description: "The total number of times this StackScript has been deployed.",
+				Computed:    true,
+			},
+			"deployments_active": &schema.Schema{
+				Type:        schema.TypeInt,
+				Description: "Count of currently active, deployed Linodes created from this StackScript.",
+				Computed:    true,
+			},
+			"created": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "The date this StackScript was created.",
+				Computed:    true,
+			},
+			"updated": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "The date this StackScript was updated.",
+				Computed:    true,
+			},
+			"user_defined_fields": &schema.Schema{
+				Type:        schema.TypeList,
+				Description: "The user-defined fields declared by the UDF tags of the script, which are the inputs of this StackScript.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:        schema.TypeString,
+							Description: "The name of the field, which is the key used in stackscript_data.",
+							Computed:    true,
+						},
+						"label": &schema.Schema{
+							Type:        schema.TypeString,
+							Description: "A human-readable label for the field.",
+							Computed:    true,
+						},
+						"default": &schema.Schema{
+							Type:        schema.TypeString,
+							Description: "The default value of the field.",
+							Computed:    true,
+						},
+						"oneof": &schema.Schema{
+							Type:        schema.TypeString,
+							Description: "A comma separated list of the values the field accepts.",
+							Computed:    true,
+						},
+						"manyof": &schema.Schema{
+							Type:        schema.TypeString,
+							Description: "A comma separated list of the values the field accepts, any number of which may be chosen.",
+							Computed:    true,
+						},
+						"example": &schema.Schema{
+							Type:        schema.TypeString,
+							Description: "An example value for the field.",
+							Computed:    true,
+						},
+						"required": &schema.Schema{
+							Type:        schema.TypeBool,
+							Description: "Whether the field has no default and must be given a value.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceLinodeStackscriptRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(linodego.Client)
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("Failed to parse Linode StackScript ID %s as int because %s", d.Id(), err)
+	}
+
+	stackscript, err := client.GetStackscript(int(id))
+	if err != nil {
+		if lerr, ok := err.(*linodego.Error); ok && lerr.Code == 404 {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Failed to find the specified Linode StackScript because %s", err)
+	}
+
+	d.Set("label", stackscript.Label)
+	d.Set("script", stackscript.Script)
+	d.Set("description", stackscript.Description)
+	d.Set("images", stackscript.Images)
+	d.Set("is_public", stackscript.IsPublic)
+	d.Set("rev_note", stackscript.RevNote)
+	d.Set("username", stackscript.Username)
+	d.Set("deployments_total", stackscript.DeploymentsTotal)
+	d.Set("deployments_active", stackscript.DeploymentsActive)
+	if stackscript.Created != nil {
+		d.Set("created", stackscript.Created.Format(time.RFC3339))
+	}
+	if stackscript.Updated != nil {
+		d.Set("updated", stackscript.Updated.Format(time.RFC3339))
+	}
+	d.Set("user_defined_fields", flattenStackscriptUDFs(parseStackscriptUDFs(stackscript.Script)))
+
+	return nil
+}
+
+func resourceLinodeStackscriptCreate(d *schema.ResourceData, meta interface{}) error {
+	client, ok := meta.(linodego.Client)
+	if !ok {
+		return fmt.Errorf("Invalid Client when creating Linode StackScript")
+	}
+
+	createOpts := linodego.StackscriptCreateOptions{
+		Label:       d.Get("label").(string),
+		Script:      d.Get("script").(string),
+		Description: d.Get("description").(string),
+		Images:      expandStringList(d.Get("images").(*schema.Set).List()),
+		IsPublic:    d.Get("is_public").(bool),
+		RevNote:     d.Get("rev_note").(string),
+	}
+	stackscript, err := client.CreateStackscript(&createOpts)
+	if err != nil {
+		return fmt.Errorf("Failed to create a Linode StackScript because %s", err)
+	}
+	d.SetId(fmt.Sprintf("%d", stackscript.ID))
+
+	return resourceLinodeStackscriptRead(d, meta)
+}
+
+func resourceLinodeStackscriptUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(linodego.Client)
+
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("Failed to parse Linode StackScript ID %s as int because %s", d.Id(), err)
+	}
+
+	stackscript, err := client.GetStackscript(int(id))
+	if err != nil {
+		return fmt.Errorf("Failed to fetch data about the current Linode StackScript because %s", err)
+	}
+
+	updateOpts := stackscript.GetUpdateOptions()
+	updateOpts.Label = d.Get("label").(string)
+	updateOpts.Script = d.Get("script").(string)
+	updateOpts.Description = d.Get("description").(string)
+	updateOpts.Images = expandStringList(d.Get("images").(*schema.Set).List())
+	updateOpts.IsPublic = d.Get("is_public").(bool)
+	updateOpts.RevNote = d.Get("rev_note").(string)
+
+	if _, err = client.UpdateStackscript(stackscript.ID, updateOpts); err != nil {
+		return fmt.Errorf("Failed to update Linode StackScript %d because %s", stackscript.ID, err)
+	}
+
+	return resourceLinodeStackscriptRead(d, meta)
+}
+
+func resourceLinodeStackscriptDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(linodego.Client)
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("Failed to parse Linode StackScript ID %s as int", d.Id())
+	}
+	err = client.DeleteStackscript(int(id))
+	if err != nil {
+		return fmt.Errorf("Failed to delete Linode StackScript %d because %s", id, err)
+	}
+	return nil
+}
+
+// resourceLinodeStackscriptCustomizeDiff plans the user-defined fields of a changed script and
+// rejects making a public StackScript private, which the API does not support
+func resourceLinodeStackscriptCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" && d.HasChange("is_public") {
+		if o, n := d.GetChange("is_public"); o.(bool) && !n.(bool) {
+			return fmt.Errorf("Linode StackScript %s is public and can not be made private", d.Id())
+		}
+	}
+
+	if !d.NewValueKnown("script") {
+		return d.SetNewComputed("user_defined_fields")
+	}
+	if d.HasChange("script") {
+		return d.SetNew("user_defined_fields", flattenStackscriptUDFs(parseStackscriptUDFs(d.Get("script").(string))))
+	}
+	return nil
+}
+
+// parseStackscriptUDFs returns the user-defined fields declared by the UDF tags of a script, in order.
+// A field without a default attribute is required.
+func parseStackscriptUDFs(script string) []stackscriptUDF {
+	var udfs []stackscriptUDF
+
+	for _, tag := range stackscriptUDFTag.FindAllStringSubmatch(script, -1) {
+		udf := stackscriptUDF{Required: true}
+		for _, attr := range stackscriptUDFAttr.FindAllStringSubmatch(tag[1], -1) {
+			// Only one of the double and single quoted values matched
+			value := attr[2] + attr[3]
+			switch strings.ToLower(attr[1]) {
+			case "name":
+				udf.Name = value
+			case "label":
+				udf.Label = value
+			case "default":
+				udf.Default = value
+				udf.Required = false
+			case "oneof":
+				udf.OneOf = value
+			case "manyof":
+				udf.ManyOf = value
+			case "example":
+				udf.Example = value
+			}
+		}
+		if udf.Name != "" {
+			udfs = append(udfs, udf)
+		}
+	}
+
+	return udfs
+}
+
+// flattenStackscriptUDFs converts user-defined fields into the user_defined_fields attribute
+func flattenStackscriptUDFs(udfs []stackscriptUDF) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(udfs))
+	for _, udf := range udfs {
+		result = append(result, map[string]interface{}{
+			"name":     udf.Name,
+			"label":    udf.Label,
+			"default":  udf.Default,
+			"oneof":    udf.OneOf,
+			"manyof":   udf.ManyOf,
+			"example":  udf.Example,
+			"required": udf.Required,
+		})
+	}
+	return result
+}
+
+// expandStackscriptData checks the values given for the user-defined fields of a StackScript and fills in the defaults
+// of the fields that were not given. Required fields must be given, and the values of oneof and manyof fields must be
+// in their lists.
+func expandStackscriptData(udfs []stackscriptUDF, data map[string]string) (map[string]string, error) {
+	declared := make(map[string]bool, len(udfs))
+	result := make(map[string]string, len(udfs))
+
+	for _, udf := range udfs {
+		declared[udf.Name] = true
+
+		value, ok := data[udf.Name]
+		if !ok {
+			if udf.Required {
+				return nil, fmt.Errorf("%s (%s) is required", udf.Name, udf.Label)
+			}
+			value = udf.Default
+		}
+
+		if udf.OneOf != "" && !stackscriptListContains(udf.OneOf, value) {
+			return nil, fmt.Errorf("%s must be one of %s, got %q", udf.Name, udf.OneOf, value)
+		}
+		if udf.ManyOf != "" && value != "" {
+			for _, item := range strings.Split(value, ",") {
+				if !stackscriptListContains(udf.ManyOf, item) {
+					return nil, fmt.Errorf("%s must be any of %s, got %q", udf.Name, udf.ManyOf, item)
+				}
+			}
+		}
+
+		result[udf.Name] = value
+	}
+
+	for name := range data {
+		if !declared[name] {
+			return nil, fmt.Errorf("%s is not a user-defined field of the StackScript", name)
+		}
+	}
+
+	return result, nil
+}
+
+// stackscriptListContains reports whether value is one of the items of a comma separated UDF list
+func stackscriptListContains(list string, value string) bool {
+	for _, item := range strings.Split(list, ",") {
+		if strings.TrimSpace(item) == strings.TrimSpace(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// stackscriptSupportsImage reports whether a StackScript can be deployed with an image
+func stackscriptSupportsImage(stackscript *linodego.Stackscript, image string) bool {
+	if len(stackscript.Images) == 0 {
+		return true
+	}
+	for _, supported := range stackscript.Images {
+		if supported == image || supported == "any/all" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,267 @@
+package linode
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/chiefy/linodego"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccLinodeStackscriptBasic(t *testing.T) {
+	t.Parallel()
+
+	resName := "linode_stackscript.foobar"
+	var stackscriptName = fmt.Sprintf("tf_test_%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLinodeStackscriptDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckLinodeStackscriptConfigBasic(stackscriptName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeStackscriptExists,
+					resource.TestCheckResourceAttr(resName, "label", stackscriptName),
+					resource.TestCheckResourceAttr(resName, "description", "tf_test stackscript"),
+					resource.TestCheckResourceAttr(resName, "is_public", "false"),
+					resource.TestCheckResourceAttr(resName, "images.#", "2"),
+					resource.TestCheckResourceAttr(resName, "user_defined_fields.#", "2"),
+					resource.TestCheckResourceAttr(resName, "user_defined_fields.0.name", "hostname"),
+					resource.TestCheckResourceAttr(resName, "user_defined_fields.0.required", "true"),
+					resource.TestCheckResourceAttr(resName, "user_defined_fields.1.name", "engine"),
+					resource.TestCheckResourceAttr(resName, "user_defined_fields.1.oneof", "mysql,postgres"),
+					resource.TestCheckResourceAttr(resName, "user_defined_fields.1.default", "mysql"),
+				),
+			},
+
+			resource.TestStep{
+				ResourceName:      resName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccLinodeStackscriptUpdate(t *testing.T) {
+	t.Parallel()
+
+	resName := "linode_stackscript.foobar"
+	var stackscriptName = fmt.Sprintf("tf_test_%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLinodeStackscriptDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckLinodeStackscriptConfigBasic(stackscriptName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeStackscriptExists,
+					resource.TestCheckResourceAttr(resName, "user_defined_fields.#", "2"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccCheckLinodeStackscriptConfigUpdates(stackscriptName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeStackscriptExists,
+					resource.TestCheckResourceAttr(resName, "label", fmt.Sprintf("%s_r", stackscriptName)),
+					resource.TestCheckResourceAttr(resName, "rev_note", "second"),
+					resource.TestCheckResourceAttr(resName, "user_defined_fields.#", "1"),
+					resource.TestCheckResourceAttr(resName, "user_defined_fields.0.name", "hostname"),
+				),
+			},
+		},
+	})
+}
+
+func TestParseStackscriptUDFs(t *testing.T) {
+	cases := []struct {
+		description string
+		script      string
+		expected    []stackscriptUDF
+	}{
+		{
+			description: "attributes in any order",
+			script:      `# <UDF label="Host name" example="web1" name="hostname">`,
+			expected:    []stackscriptUDF{{Name: "hostname", Label: "Host name", Example: "web1", Required: true}},
+		},
+		{
+			description: "single quotes",
+			script:      `# <UDF name='engine' label='Database' default='mysql' oneof='mysql,postgres' />`,
+			expected:    []stackscriptUDF{{Name: "engine", Label: "Database", Default: "mysql", OneOf: "mysql,postgres"}},
+		},
+		{
+			description: "mixed quotes and case",
+			script:      `# <udf NAME="greeting" Label='Say "hi" > there' DEFAULT="" />`,
+			expected:    []stackscriptUDF{{Name: "greeting", Label: `Say "hi" > there`}},
+		},
+		{
+			description: "manyof",
+			script:      `# <UDF name="packages" label="Packages" manyof="git,vim,curl" default="git,vim">`,
+			expected:    []stackscriptUDF{{Name: "packages", Label: "Packages", ManyOf: "git,vim,curl", Default: "git,vim"}},
+		},
+		{
+			description: "several fields in order, skipping one without a name",
+			script: `#!/bin/bash
+# <UDF name="user" label="User">
+# <UDF label="Nameless" default="x">
+# <UDF name="shell" label="Shell" default="/bin/bash">
+echo done`,
+			expected: []stackscriptUDF{
+				{Name: "user", Label: "User", Required: true},
+				{Name: "shell", Label: "Shell", Default: "/bin/bash"},
+			},
+		},
+		{
+			description: "no fields",
+			script:      "#!/bin/bash\necho '<not a UDF>'",
+			expected:    nil,
+		},
+	}
+
+	for _, c := range cases {
+		if udfs := parseStackscriptUDFs(c.script); !reflect.DeepEqual(udfs, c.expected) {
+			t.Errorf("%s: parsed %#v, expected %#v", c.description, udfs, c.expected)
+		}
+	}
+}
+
+func TestExpandStackscriptData(t *testing.T) {
+	udfs := []stackscriptUDF{
+		{Name: "hostname", Label: "Host name", Required: true},
+		{Name: "engine", Label: "Database", Default: "mysql", OneOf: "mysql, postgres"},
+		{Name: "packages", Label: "Packages", Default: "", ManyOf: "git,vim,curl"},
+	}
+
+	cases := []struct {
+		data     map[string]string
+		expected map[string]string
+		err      string
+	}{
+		{
+			data:     map[string]string{"hostname": "web1"},
+			expected: map[string]string{"hostname": "web1", "engine": "mysql", "packages": ""},
+		},
+		{
+			data:     map[string]string{"hostname": "web1", "engine": "postgres", "packages": "git,curl"},
+			expected: map[string]string{"hostname": "web1", "engine": "postgres", "packages": "git,curl"},
+		},
+		{
+			data: map[string]string{"engine": "mysql"},
+			err:  "hostname (Host name) is required",
+		},
+		{
+			data: map[string]string{"hostname": "web1", "engine": "sqlite"},
+			err:  `engine must be one of mysql, postgres, got "sqlite"`,
+		},
+		{
+			data: map[string]string{"hostname": "web1", "packages": "git,emacs"},
+			err:  `packages must be any of git,vim,curl, got "emacs"`,
+		},
+		{
+			data: map[string]string{"hostname": "web1", "region": "us-east"},
+			err:  "region is not a user-defined field of the StackScript",
+		},
+	}
+
+	for _, c := range cases {
+		data, err := expandStackscriptData(udfs, c.data)
+		switch {
+		case c.err != "" && (err == nil || err.Error() != c.err):
+			t.Errorf("expandStackscriptData(%v) returned error %v, expected %q", c.data, err, c.err)
+		case c.err == "" && err != nil:
+			t.Errorf("expandStackscriptData(%v) returned unexpected error %s", c.data, err)
+		case c.err == "" && !reflect.DeepEqual(data, c.expected):
+			t.Errorf("expandStackscriptData(%v) = %v, expected %v", c.data, data, c.expected)
+		}
+	}
+}
+
+func testAccCheckLinodeStackscriptExists(s *terraform.State) error {
+	client := testAccProvider.Meta().(linodego.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "linode_stackscript" {
+			continue
+		}
+
+		id, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("Failed parsing %v to int", rs.Primary.ID)
+		}
+
+		_, err = client.GetStackscript(id)
+		if err != nil {
+			return fmt.Errorf("Error retrieving state of StackScript %s: %s", rs.Primary.Attributes["label"], err)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckLinodeStackscriptDestroy(s *terraform.State) error {
+	client, ok := testAccProvider.Meta().(linodego.Client)
+	if !ok {
+		return fmt.Errorf("Failed to get Linode client")
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "linode_stackscript" {
+			continue
+		}
+
+		id, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("Failed parsing %v to int", rs.Primary.ID)
+		}
+
+		_, err = client.GetStackscript(id)
+
+		if err == nil {
+			return fmt.Errorf("Linode StackScript with id %d still exists", id)
+		}
+
+		if apiErr, ok := err.(*linodego.Error); ok && apiErr.Code != 404 {
+			return fmt.Errorf("Failed to request Linode StackScript with id %d", id)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckLinodeStackscriptConfigBasic(stackscript string) string {
+	return fmt.Sprintf(`
+resource "linode_stackscript" "foobar" {
+	label = "%s"
+	description = "tf_test stackscript"
+	images = ["linode/ubuntu18.04", "linode/debian9"]
+	rev_note = "initial"
+	script = <<EOF
+#!/bin/bash
+# <UDF name="hostname" label="The hostname of the Linode" example="web1" />
+# <UDF name="engine" label="Database engine" oneof="mysql,postgres" default="mysql" />
+hostnamectl set-hostname "$HOSTNAME"
+EOF
+}`, stackscript)
+}
+
+func testAccCheckLinodeStackscriptConfigUpdates(stackscript string) string {
+	return fmt.Sprintf(`
+resource "linode_stackscript" "foobar" {
+	label = "%s_r"
+	description = "tf_test stackscript"
+	images = ["linode/ubuntu18.04"]
+	rev_note = "second"
+	script = <<EOF
+#!/bin/bash
+# <UDF name="hostname" label="The hostname of the Linode" example="web1" />
+hostnamectl set-hostname "$HOSTNAME"
+EOF
+}`, stackscript)
+}
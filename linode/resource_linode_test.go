@@ -2,13 +2,17 @@ package linode
 
 import (
 	"fmt"
+	"net"
+	"regexp"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/chiefy/linodego"
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
+	"golang.org/x/crypto/ssh"
 )
 
 func TestAccLinodeLinodeBasic(t *testing.T) {
@@ -162,17 +166,601 @@ func TestAccLinodeLinodeExpandDisk(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckLinodeLinodeExists,
 					resource.TestCheckResourceAttr("linode_linode.foobar", "type", "g6-standard-1"),
+					resource.TestCheckResourceAttr("linode_linode.foobar", "plan_storage_utilized", "51200"),
+				),
+			},
+			// The expanded disk doesn't fit a 1024 unless it may be shrunk
+			resource.TestStep{
+				Config:      testAccCheckLinodeLinodeConfigDownsizeShrinkDisk(instanceName, publicKeyMaterial, false),
+				ExpectError: regexp.MustCompile("set disk_expansion to shrink the largest disk"),
+			},
+			resource.TestStep{
+				Config: testAccCheckLinodeLinodeConfigDownsizeShrinkDisk(instanceName, publicKeyMaterial, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeLinodeExists,
+					resource.TestCheckResourceAttr("linode_linode.foobar", "type", "g6-nanode-1"),
 					resource.TestCheckResourceAttr("linode_linode.foobar", "plan_storage_utilized", "25600"),
+					resource.TestCheckResourceAttr("linode_linode.foobar", "swap_size", "256"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccLinodeLinodePrivateNetworking(t *testing.T) {
+	t.Parallel()
+
+	var instanceName = fmt.Sprintf("tf_test_%s", acctest.RandString(10))
+	publicKeyMaterial, _, err := acctest.RandSSHKeyPair("linode@ssh-acceptance-test")
+	if err != nil {
+		t.Fatalf("Cannot generate test SSH key pair: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLinodeLinodeDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckLinodeLinodeConfigPrivateNetworking(instanceName, publicKeyMaterial),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeLinodeExists,
+					testAccCheckLinodeLinodeAttributesPrivateNetworking("linode_linode.foobar"),
+					resource.TestCheckResourceAttr("linode_linode.foobar", "private_networking", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccLinodeLinodeStackscript(t *testing.T) {
+	t.Parallel()
+
+	resName := "linode_linode.foobar"
+	var instanceName = fmt.Sprintf("tf_test_%s", acctest.RandString(10))
+	publicKeyMaterial, _, err := acctest.RandSSHKeyPair("linode@ssh-acceptance-test")
+	if err != nil {
+		t.Fatalf("Cannot generate test SSH key pair: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLinodeLinodeDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckLinodeLinodeConfigStackscript(instanceName, publicKeyMaterial, `hostname = "web1"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeLinodeExists,
+					resource.TestCheckResourceAttrPair(resName, "stackscript_id", "linode_stackscript.foobar", "id"),
+					resource.TestCheckResourceAttr(resName, "stackscript_data.%", "2"),
+					resource.TestCheckResourceAttr(resName, "stackscript_data.hostname", "web1"),
+					resource.TestCheckResourceAttr(resName, "stackscript_data.engine", "mysql"),
+				),
+			},
+			resource.TestStep{
+				Config:      testAccCheckLinodeLinodeConfigStackscript(instanceName, publicKeyMaterial, `engine = "sqlite"`),
+				ExpectError: regexp.MustCompile("hostname \\(.*\\) is required"),
+			},
+			resource.TestStep{
+				Config:      testAccCheckLinodeLinodeConfigStackscript(instanceName, publicKeyMaterial, `hostname = "web1", engine = "sqlite"`),
+				ExpectError: regexp.MustCompile("engine must be one of mysql,postgres"),
+			},
+		},
+	})
+}
+
+func TestAccLinodeLinodeRebuild(t *testing.T) {
+	t.Parallel()
+
+	resName := "linode_linode.foobar"
+	var instanceName = fmt.Sprintf("tf_test_%s", acctest.RandString(10))
+	var instanceID, ipAddress string
+	publicKeyMaterial, _, err := acctest.RandSSHKeyPair("linode@ssh-acceptance-test")
+	if err != nil {
+		t.Fatalf("Cannot generate test SSH key pair: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLinodeLinodeDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckLinodeLinodeConfigRebuild(instanceName, publicKeyMaterial, "linode/ubuntu18.04", "terraform-test"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeLinodeExists,
+					resource.TestCheckResourceAttr(resName, "rebuild_in_place", "true"),
+					testAccCheckResourceAttrCapture(resName, "id", &instanceID),
+					testAccCheckResourceAttrCapture(resName, "ip_address", &ipAddress),
+				),
+			},
+			// Only the hash of the given root_password is left to deploy
+			resource.TestStep{
+				Config:      testAccCheckLinodeLinodeConfigRebuild(instanceName, publicKeyMaterial, "linode/debian9", "terraform-test"),
+				ExpectError: regexp.MustCompile("Change root_password along with image"),
+			},
+			// Changing the image must keep the instance and its IP address
+			resource.TestStep{
+				Config: testAccCheckLinodeLinodeConfigRebuild(instanceName, publicKeyMaterial, "linode/debian9", "terraform-test-rebuild"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeLinodeExists,
+					resource.TestCheckResourceAttrPtr(resName, "id", &instanceID),
+					resource.TestCheckResourceAttrPtr(resName, "ip_address", &ipAddress),
+					resource.TestCheckResourceAttr(resName, "image", "linode/debian9"),
+					resource.TestCheckResourceAttr(resName, "swap_size", "256"),
+					testAccCheckLinodeLinodeSSHLogin(resName, "terraform-test-rebuild"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccLinodeLinodeRebuildGeneratedPassword(t *testing.T) {
+	t.Parallel()
+
+	resName := "linode_linode.foobar"
+	var instanceName = fmt.Sprintf("tf_test_%s", acctest.RandString(10))
+	var instanceID, rootPassword string
+	publicKeyMaterial, _, err := acctest.RandSSHKeyPair("linode@ssh-acceptance-test")
+	if err != nil {
+		t.Fatalf("Cannot generate test SSH key pair: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLinodeLinodeDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckLinodeLinodeConfigRebuild(instanceName, publicKeyMaterial, "linode/ubuntu18.04", ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeLinodeExists,
+					testAccCheckResourceAttrCapture(resName, "id", &instanceID),
+					testAccCheckResourceAttrCapture(resName, "root_password", &rootPassword),
+				),
+			},
+			// The generated root_password is kept in the state, so changing only the image deploys it again
+			resource.TestStep{
+				Config: testAccCheckLinodeLinodeConfigRebuild(instanceName, publicKeyMaterial, "linode/debian9", ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeLinodeExists,
+					resource.TestCheckResourceAttrPtr(resName, "id", &instanceID),
+					resource.TestCheckResourceAttrPtr(resName, "root_password", &rootPassword),
+					resource.TestCheckResourceAttr(resName, "image", "linode/debian9"),
+					testAccCheckLinodeLinodeSSHLogin(resName, ""),
+				),
+			},
+		},
+	})
+}
+
+func TestAccLinodeLinodeSwapSize(t *testing.T) {
+	t.Parallel()
+
+	resName := "linode_linode.foobar"
+	var instanceName = fmt.Sprintf("tf_test_%s", acctest.RandString(10))
+	var instanceID string
+	publicKeyMaterial, _, err := acctest.RandSSHKeyPair("linode@ssh-acceptance-test")
+	if err != nil {
+		t.Fatalf("Cannot generate test SSH key pair: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLinodeLinodeDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckLinodeLinodeConfigSwapSize(instanceName, publicKeyMaterial, 256),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeLinodeExists,
+					resource.TestCheckResourceAttr(resName, "swap_size", "256"),
+					testAccCheckResourceAttrCapture(resName, "id", &instanceID),
+				),
+			},
+			// Growing swap must shrink the root disk, which fills the plan storage
+			resource.TestStep{
+				Config: testAccCheckLinodeLinodeConfigSwapSize(instanceName, publicKeyMaterial, 1024),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeLinodeExists,
+					resource.TestCheckResourceAttrPtr(resName, "id", &instanceID),
+					resource.TestCheckResourceAttr(resName, "swap_size", "1024"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccCheckLinodeLinodeConfigSwapSize(instanceName, publicKeyMaterial, 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeLinodeExists,
+					resource.TestCheckResourceAttrPtr(resName, "id", &instanceID),
+					resource.TestCheckResourceAttr(resName, "swap_size", "0"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccCheckLinodeLinodeConfigSwapSize(instanceName, publicKeyMaterial, 512),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeLinodeExists,
+					resource.TestCheckResourceAttrPtr(resName, "id", &instanceID),
+					resource.TestCheckResourceAttr(resName, "swap_size", "512"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccLinodeLinodeMigrate(t *testing.T) {
+	t.Parallel()
+
+	resName := "linode_linode.foobar"
+	var instanceName = fmt.Sprintf("tf_test_%s", acctest.RandString(10))
+	var instanceID string
+	publicKeyMaterial, _, err := acctest.RandSSHKeyPair("linode@ssh-acceptance-test")
+	if err != nil {
+		t.Fatalf("Cannot generate test SSH key pair: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLinodeLinodeDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckLinodeLinodeConfigMigrate(instanceName, publicKeyMaterial, "us-east"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeLinodeExists,
+					resource.TestCheckResourceAttr(resName, "region", "us-east"),
+					testAccCheckResourceAttrCapture(resName, "id", &instanceID),
+				),
+			},
+			// Changing the region must keep the instance
+			resource.TestStep{
+				Config: testAccCheckLinodeLinodeConfigMigrate(instanceName, publicKeyMaterial, "us-central"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeLinodeExists,
+					resource.TestCheckResourceAttrPtr(resName, "id", &instanceID),
+					resource.TestCheckResourceAttr(resName, "region", "us-central"),
+					resource.TestCheckResourceAttrSet(resName, "ip_address"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccLinodeLinodeBooted(t *testing.T) {
+	t.Parallel()
+
+	resName := "linode_linode.foobar"
+	var instanceName = fmt.Sprintf("tf_test_%s", acctest.RandString(10))
+	publicKeyMaterial, _, err := acctest.RandSSHKeyPair("linode@ssh-acceptance-test")
+	if err != nil {
+		t.Fatalf("Cannot generate test SSH key pair: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLinodeLinodeDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckLinodeLinodeConfigBooted(instanceName, publicKeyMaterial, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeLinodeExists,
+					resource.TestCheckResourceAttr(resName, "booted", "false"),
+					resource.TestCheckResourceAttr(resName, "status", "offline"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccCheckLinodeLinodeConfigBooted(instanceName, publicKeyMaterial, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeLinodeExists,
+					resource.TestCheckResourceAttr(resName, "booted", "true"),
+					resource.TestCheckResourceAttr(resName, "status", "running"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccCheckLinodeLinodeConfigBooted(instanceName, publicKeyMaterial, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeLinodeExists,
+					resource.TestCheckResourceAttr(resName, "booted", "false"),
+					resource.TestCheckResourceAttr(resName, "status", "offline"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccLinodeLinodeBackups(t *testing.T) {
+	t.Parallel()
+
+	resName := "linode_linode.foobar"
+	var instanceName = fmt.Sprintf("tf_test_%s", acctest.RandString(10))
+	publicKeyMaterial, _, err := acctest.RandSSHKeyPair("linode@ssh-acceptance-test")
+	if err != nil {
+		t.Fatalf("Cannot generate test SSH key pair: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLinodeLinodeDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckLinodeLinodeConfigBackups(instanceName, publicKeyMaterial, "W10"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeLinodeExists,
+					resource.TestCheckResourceAttr(resName, "backups_enabled", "true"),
+					resource.TestCheckResourceAttr(resName, "backups_schedule.0.day", "Saturday"),
+					resource.TestCheckResourceAttr(resName, "backups_schedule.0.window", "W10"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccCheckLinodeLinodeConfigBackups(instanceName, publicKeyMaterial, "W12"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeLinodeExists,
+					resource.TestCheckResourceAttr(resName, "backups_enabled", "true"),
+					resource.TestCheckResourceAttr(resName, "backups_schedule.0.window", "W12"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccCheckLinodeLinodeConfigBasic(instanceName, publicKeyMaterial),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeLinodeExists,
+					resource.TestCheckResourceAttr(resName, "backups_enabled", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccLinodeLinodeAlerts(t *testing.T) {
+	t.Parallel()
+
+	resName := "linode_linode.foobar"
+	var instanceName = fmt.Sprintf("tf_test_%s", acctest.RandString(10))
+	publicKeyMaterial, _, err := acctest.RandSSHKeyPair("linode@ssh-acceptance-test")
+	if err != nil {
+		t.Fatalf("Cannot generate test SSH key pair: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLinodeLinodeDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckLinodeLinodeConfigBasic(instanceName, publicKeyMaterial),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeLinodeExists,
+					resource.TestCheckResourceAttr(resName, "alerts.#", "1"),
+					resource.TestCheckResourceAttrSet(resName, "alerts.0.cpu"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccCheckLinodeLinodeConfigAlerts(instanceName, publicKeyMaterial, 0, 20000),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeLinodeExists,
+					resource.TestCheckResourceAttr(resName, "alerts.0.cpu", "0"),
+					resource.TestCheckResourceAttr(resName, "alerts.0.io", "20000"),
+					resource.TestCheckResourceAttrSet(resName, "alerts.0.transfer_quota"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccCheckLinodeLinodeConfigAlerts(instanceName, publicKeyMaterial, 90, 10000),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeLinodeExists,
+					resource.TestCheckResourceAttr(resName, "alerts.0.cpu", "90"),
+					resource.TestCheckResourceAttr(resName, "alerts.0.io", "10000"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccLinodeLinodeClone(t *testing.T) {
+	t.Parallel()
+
+	resName := "linode_linode.clone"
+	var instanceName = fmt.Sprintf("tf_test_%s", acctest.RandString(10))
+	publicKeyMaterial, _, err := acctest.RandSSHKeyPair("linode@ssh-acceptance-test")
+	if err != nil {
+		t.Fatalf("Cannot generate test SSH key pair: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLinodeLinodeDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckLinodeLinodeConfigClone(instanceName, publicKeyMaterial, ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeLinodeExists,
+					resource.TestCheckResourceAttrPair(resName, "source_linode_id", "linode_linode.foobar", "id"),
+					resource.TestCheckResourceAttr(resName, "type", "g6-standard-1"),
+					resource.TestCheckResourceAttr(resName, "disk.#", "2"),
+					resource.TestCheckResourceAttr(resName, "swap_size", "256"),
+					resource.TestCheckResourceAttr(resName, "status", "running"),
+				),
+			},
+			// Cloning only the root disk and the config
+			resource.TestStep{
+				Config: testAccCheckLinodeLinodeConfigClone(instanceName, publicKeyMaterial, `
+	source_disks = ["linode${linode_linode.foobar.id}-root", "linode${linode_linode.foobar.id}-swap"]
+	source_configs = ["linode${linode_linode.foobar.id}-config"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeLinodeExists,
+					resource.TestCheckResourceAttr(resName, "source_disks.#", "2"),
+					resource.TestCheckResourceAttr(resName, "disk.#", "2"),
+					resource.TestCheckResourceAttr(resName, "status", "running"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccLinodeLinodeRootPassword(t *testing.T) {
+	t.Parallel()
+
+	resName := "linode_linode.foobar"
+	var instanceName = fmt.Sprintf("tf_test_%s", acctest.RandString(10))
+	var instanceID string
+	publicKeyMaterial, _, err := acctest.RandSSHKeyPair("linode@ssh-acceptance-test")
+	if err != nil {
+		t.Fatalf("Cannot generate test SSH key pair: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLinodeLinodeDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckLinodeLinodeConfigBasic(instanceName, publicKeyMaterial),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeLinodeExists,
+					testAccCheckResourceAttrCapture(resName, "id", &instanceID),
+				),
+			},
+			// Changing the root password must keep the instance
+			resource.TestStep{
+				Config: testAccCheckLinodeLinodeConfigRootPassword(instanceName, publicKeyMaterial, "terraform-test-rotated"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeLinodeExists,
+					resource.TestCheckResourceAttrPtr(resName, "id", &instanceID),
+					resource.TestCheckResourceAttr(resName, "status", "running"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccLinodeLinodeRescue(t *testing.T) {
+	t.Parallel()
+
+	resName := "linode_linode.foobar"
+	var instanceName = fmt.Sprintf("tf_test_%s", acctest.RandString(10))
+	publicKeyMaterial, _, err := acctest.RandSSHKeyPair("linode@ssh-acceptance-test")
+	if err != nil {
+		t.Fatalf("Cannot generate test SSH key pair: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLinodeLinodeDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckLinodeLinodeConfigRescue(instanceName, publicKeyMaterial, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeLinodeExists,
+					resource.TestCheckResourceAttr(resName, "rescue.#", "1"),
+					resource.TestCheckResourceAttr(resName, "rescue.0.devices.0.sda.0.disk_label", "root"),
+					resource.TestCheckResourceAttr(resName, "status", "running"),
+				),
+			},
+			// Removing the rescue block boots the config again
+			resource.TestStep{
+				Config: testAccCheckLinodeLinodeConfigRescue(instanceName, publicKeyMaterial, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeLinodeExists,
+					resource.TestCheckResourceAttr(resName, "rescue.#", "0"),
+					resource.TestCheckResourceAttr(resName, "status", "running"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccLinodeLinodeGeneratedRootPassword(t *testing.T) {
+	t.Parallel()
+
+	resName := "linode_linode.foobar"
+	var instanceName = fmt.Sprintf("tf_test_%s", acctest.RandString(10))
+	var rootPassword string
+	publicKeyMaterial, _, err := acctest.RandSSHKeyPair("linode@ssh-acceptance-test")
+	if err != nil {
+		t.Fatalf("Cannot generate test SSH key pair: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLinodeLinodeDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckLinodeLinodeConfigGeneratedRootPassword(instanceName, publicKeyMaterial),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeLinodeExists,
+					resource.TestCheckResourceAttrSet(resName, "root_password"),
+					testAccCheckResourceAttrCapture(resName, "root_password", &rootPassword),
+					resource.TestCheckResourceAttr(resName, "status", "running"),
+				),
+			},
+			// The generated password is kept
+			resource.TestStep{
+				Config: testAccCheckLinodeLinodeConfigGeneratedRootPassword(instanceName, publicKeyMaterial),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeLinodeExists,
+					resource.TestCheckResourceAttrPtr(resName, "root_password", &rootPassword),
+				),
+			},
+		},
+	})
+}
+
+func TestAccLinodeLinodeDisks(t *testing.T) {
+	t.Parallel()
+
+	resName := "linode_linode.foobar"
+	var instanceName = fmt.Sprintf("tf_test_%s", acctest.RandString(10))
+	var dataDiskID string
+	publicKeyMaterial, _, err := acctest.RandSSHKeyPair("linode@ssh-acceptance-test")
+	if err != nil {
+		t.Fatalf("Cannot generate test SSH key pair: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLinodeLinodeDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckLinodeLinodeConfigDisks(instanceName, publicKeyMaterial),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeLinodeExists,
+					resource.TestCheckResourceAttr(resName, "disk.#", "3"),
+					resource.TestCheckResourceAttr(resName, "disk.0.label", "root"),
+					resource.TestCheckResourceAttr(resName, "disk.0.filesystem", "ext4"),
+					resource.TestCheckResourceAttr(resName, "disk.1.label", "swap"),
+					resource.TestCheckResourceAttr(resName, "disk.1.filesystem", "swap"),
+					resource.TestCheckResourceAttr(resName, "disk.2.label", "data"),
+					resource.TestCheckResourceAttr(resName, "disk.2.size", "1024"),
+					resource.TestCheckResourceAttrSet(resName, "disk.2.id"),
+					testAccCheckResourceAttrCapture(resName, "disk.2.id", &dataDiskID),
+				),
+			},
+			// Resizing and renaming the data disk must keep it, removing swap must delete it
+			resource.TestStep{
+				Config: testAccCheckLinodeLinodeConfigDisksUpdates(instanceName, publicKeyMaterial),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeLinodeExists,
+					resource.TestCheckResourceAttr(resName, "disk.#", "2"),
+					resource.TestCheckResourceAttr(resName, "disk.0.label", "root"),
+					resource.TestCheckResourceAttr(resName, "disk.1.label", "db"),
+					resource.TestCheckResourceAttr(resName, "disk.1.size", "2048"),
+					resource.TestCheckResourceAttrPtr(resName, "disk.1.id", &dataDiskID),
 				),
 			},
 		},
 	})
 }
 
-func TestAccLinodeLinodePrivateNetworking(t *testing.T) {
+func TestAccLinodeLinodeImageConfigs(t *testing.T) {
 	t.Parallel()
 
+	resName := "linode_linode.foobar"
 	var instanceName = fmt.Sprintf("tf_test_%s", acctest.RandString(10))
+	var instanceID string
 	publicKeyMaterial, _, err := acctest.RandSSHKeyPair("linode@ssh-acceptance-test")
 	if err != nil {
 		t.Fatalf("Cannot generate test SSH key pair: %s", err)
@@ -184,11 +772,70 @@ func TestAccLinodeLinodePrivateNetworking(t *testing.T) {
 		CheckDestroy: testAccCheckLinodeLinodeDestroy,
 		Steps: []resource.TestStep{
 			resource.TestStep{
-				Config: testAccCheckLinodeLinodeConfigPrivateNetworking(instanceName, publicKeyMaterial),
+				Config: testAccCheckLinodeLinodeConfigImageConfigs(instanceName, publicKeyMaterial, "linode/ubuntu18.04", "terraform-test"),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckLinodeLinodeExists,
-					testAccCheckLinodeLinodeAttributesPrivateNetworking("linode_linode.foobar"),
-					resource.TestCheckResourceAttr("linode_linode.foobar", "private_networking", "true"),
+					testAccCheckResourceAttrCapture(resName, "id", &instanceID),
+					resource.TestCheckResourceAttr(resName, "config.#", "1"),
+					resource.TestCheckResourceAttr(resName, "config.0.kernel", "linode/grub2"),
+					resource.TestMatchResourceAttr(resName, "config.0.devices.0.sda.0.disk_label", regexp.MustCompile("^linode[0-9]+-root$")),
+					resource.TestMatchResourceAttr(resName, "config.0.devices.0.sdb.0.disk_label", regexp.MustCompile("^linode[0-9]+-swap$")),
+					resource.TestCheckResourceAttr(resName, "boot_config_label", "main"),
+				),
+			},
+			// The declared config is created again on the rebuilt disks
+			resource.TestStep{
+				Config: testAccCheckLinodeLinodeConfigImageConfigs(instanceName, publicKeyMaterial, "linode/debian9", "terraform-test-rebuild"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeLinodeExists,
+					resource.TestCheckResourceAttrPtr(resName, "id", &instanceID),
+					resource.TestCheckResourceAttr(resName, "config.#", "1"),
+					resource.TestCheckResourceAttr(resName, "config.0.kernel", "linode/grub2"),
+					resource.TestMatchResourceAttr(resName, "config.0.devices.0.sda.0.disk_label", regexp.MustCompile("^linode[0-9]+-root$")),
+					resource.TestMatchResourceAttr(resName, "config.0.devices.0.sdb.0.disk_label", regexp.MustCompile("^linode[0-9]+-swap$")),
+				),
+			},
+		},
+	})
+}
+
+func TestAccLinodeLinodeConfigs(t *testing.T) {
+	t.Parallel()
+
+	resName := "linode_linode.foobar"
+	var instanceName = fmt.Sprintf("tf_test_%s", acctest.RandString(10))
+	publicKeyMaterial, _, err := acctest.RandSSHKeyPair("linode@ssh-acceptance-test")
+	if err != nil {
+		t.Fatalf("Cannot generate test SSH key pair: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLinodeLinodeDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckLinodeLinodeConfigConfigs(instanceName, publicKeyMaterial, "main"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeLinodeExists,
+					resource.TestCheckResourceAttr(resName, "config.#", "2"),
+					resource.TestCheckResourceAttr(resName, "config.0.label", "main"),
+					resource.TestCheckResourceAttr(resName, "config.0.kernel", "linode/grub2"),
+					resource.TestCheckResourceAttr(resName, "config.0.devices.0.sda.0.disk_label", "root"),
+					resource.TestCheckResourceAttr(resName, "config.0.devices.0.sdb.0.disk_label", "swap"),
+					resource.TestCheckResourceAttr(resName, "config.1.label", "recovery"),
+					resource.TestCheckResourceAttr(resName, "config.1.run_level", "single"),
+					resource.TestCheckResourceAttr(resName, "config.1.helpers.0.network", "false"),
+					resource.TestCheckResourceAttrSet(resName, "config.1.id"),
+					resource.TestCheckResourceAttr(resName, "boot_config_label", "main"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccCheckLinodeLinodeConfigConfigs(instanceName, publicKeyMaterial, "recovery"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeLinodeExists,
+					resource.TestCheckResourceAttr(resName, "config.#", "2"),
+					resource.TestCheckResourceAttr(resName, "boot_config_label", "recovery"),
 				),
 			},
 		},
@@ -214,6 +861,37 @@ func testAccCheckLinodeLinodeExists(s *terraform.State) error {
 	return nil
 }
 
+// testAccCheckLinodeLinodeSSHLogin checks that root can log in to the Linode over SSH with the password, or with the
+// generated root_password in the state when the password is empty
+func testAccCheckLinodeLinodeSSHLogin(name string, password string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if password == "" {
+			password = rs.Primary.Attributes["root_password"]
+		}
+		config := &ssh.ClientConfig{
+			User:            "root",
+			Auth:            []ssh.AuthMethod{ssh.Password(password)},
+			HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+			Timeout:         10 * time.Second,
+		}
+		address := net.JoinHostPort(rs.Primary.Attributes["ip_address"], "22")
+
+		// sshd may still be starting once the Linode has booted
+		return resource.Retry(5*time.Minute, func() *resource.RetryError {
+			client, err := ssh.Dial("tcp", address, config)
+			if err != nil {
+				return resource.RetryableError(fmt.Errorf("Failed to log in to %s as root because %s", address, err))
+			}
+			client.Close()
+			return nil
+		})
+	}
+}
+
 func testAccCheckLinodeLinodeDestroy(s *terraform.State) error {
 	client, ok := testAccProvider.Meta().(linodego.Client)
 	if !ok {
@@ -358,6 +1036,21 @@ resource "linode_linode" "foobar" {
 }`, instance, pubkey)
 }
 
+func testAccCheckLinodeLinodeConfigDownsizeShrinkDisk(instance string, pubkey string, diskExpansion bool) string {
+	return fmt.Sprintf(`
+resource "linode_linode" "foobar" {
+	name = "%s_expanded"
+	type = "g6-nanode-1"
+	disk_expansion = %t
+	image = "linode/ubuntu18.04"
+	region = "us-east"
+	kernel = "linode/latest-64bit"
+	root_password = "terraform-test"
+	swap_size = 256
+	ssh_key = "%s"
+}`, instance, diskExpansion, pubkey)
+}
+
 func testAccCheckLinodeLinodeConfigPrivateNetworking(instance string, pubkey string) string {
 	return fmt.Sprintf(`
 resource "linode_linode" "foobar" {
@@ -372,3 +1065,344 @@ resource "linode_linode" "foobar" {
 	ssh_key = "%s"
 }`, instance, pubkey)
 }
+
+func testAccCheckLinodeLinodeConfigStackscript(instance string, pubkey string, data string) string {
+	return testAccCheckLinodeStackscriptConfigBasic(instance) + fmt.Sprintf(`
+resource "linode_linode" "foobar" {
+	name = "%s"
+	type = "g6-nanode-1"
+	image = "linode/ubuntu18.04"
+	region = "us-east"
+	kernel = "linode/latest-64bit"
+	root_password = "terraform-test"
+	swap_size = 256
+	ssh_key = "%s"
+	stackscript_id = "${linode_stackscript.foobar.id}"
+	stackscript_data = { %s }
+}`, instance, pubkey, data)
+}
+
+func testAccCheckLinodeLinodeConfigRebuild(instance string, pubkey string, image string, rootPassword string) string {
+	if rootPassword != "" {
+		rootPassword = fmt.Sprintf("root_password = %q", rootPassword)
+	}
+	return fmt.Sprintf(`
+resource "linode_linode" "foobar" {
+	name = "%s"
+	type = "g6-nanode-1"
+	image = "%s"
+	region = "us-east"
+	kernel = "linode/latest-64bit"
+	%s
+	swap_size = 256
+	ssh_key = "%s"
+	rebuild_in_place = true
+}`, instance, image, rootPassword, pubkey)
+}
+
+func testAccCheckLinodeLinodeConfigSwapSize(instance string, pubkey string, swapSize int) string {
+	return fmt.Sprintf(`
+resource "linode_linode" "foobar" {
+	name = "%s"
+	type = "g6-nanode-1"
+	image = "linode/ubuntu18.04"
+	region = "us-east"
+	kernel = "linode/latest-64bit"
+	root_password = "terraform-test"
+	swap_size = %d
+	ssh_key = "%s"
+}`, instance, swapSize, pubkey)
+}
+
+func testAccCheckLinodeLinodeConfigMigrate(instance string, pubkey string, region string) string {
+	return fmt.Sprintf(`
+resource "linode_linode" "foobar" {
+	name = "%s"
+	type = "g6-nanode-1"
+	image = "linode/ubuntu18.04"
+	region = "%s"
+	kernel = "linode/latest-64bit"
+	root_password = "terraform-test"
+	swap_size = 256
+	ssh_key = "%s"
+	migrate_on_region_change = true
+}`, instance, region, pubkey)
+}
+
+func testAccCheckLinodeLinodeConfigBooted(instance string, pubkey string, booted bool) string {
+	return fmt.Sprintf(`
+resource "linode_linode" "foobar" {
+	name = "%s"
+	type = "g6-nanode-1"
+	image = "linode/ubuntu18.04"
+	region = "us-east"
+	kernel = "linode/latest-64bit"
+	root_password = "terraform-test"
+	swap_size = 256
+	ssh_key = "%s"
+	booted = %t
+}`, instance, pubkey, booted)
+}
+
+func testAccCheckLinodeLinodeConfigBackups(instance string, pubkey string, window string) string {
+	return fmt.Sprintf(`
+resource "linode_linode" "foobar" {
+	name = "%s"
+	type = "g6-nanode-1"
+	image = "linode/ubuntu18.04"
+	region = "us-east"
+	kernel = "linode/latest-64bit"
+	root_password = "terraform-test"
+	swap_size = 256
+	ssh_key = "%s"
+	backups_enabled = true
+
+	backups_schedule {
+		day = "Saturday"
+		window = "%s"
+	}
+}`, instance, pubkey, window)
+}
+
+func testAccCheckLinodeLinodeConfigAlerts(instance string, pubkey string, cpu int, io int) string {
+	return fmt.Sprintf(`
+resource "linode_linode" "foobar" {
+	name = "%s"
+	type = "g6-nanode-1"
+	image = "linode/ubuntu18.04"
+	region = "us-east"
+	kernel = "linode/latest-64bit"
+	root_password = "terraform-test"
+	swap_size = 256
+	ssh_key = "%s"
+
+	alerts {
+		cpu = %d
+		io = %d
+	}
+}`, instance, pubkey, cpu, io)
+}
+
+func testAccCheckLinodeLinodeConfigClone(instance string, pubkey string, source string) string {
+	return testAccCheckLinodeLinodeConfigBasic(instance, pubkey) + fmt.Sprintf(`
+
+resource "linode_linode" "clone" {
+	name = "%s_clone"
+	type = "g6-standard-1"
+	region = "us-east"
+	kernel = "linode/latest-64bit"
+	source_linode_id = "${linode_linode.foobar.id}"%s
+}`, instance, source)
+}
+
+func testAccCheckLinodeLinodeConfigRootPassword(instance string, pubkey string, password string) string {
+	return fmt.Sprintf(`
+resource "linode_linode" "foobar" {
+	name = "%s"
+	type = "g6-nanode-1"
+	image = "linode/ubuntu18.04"
+	region = "us-east"
+	kernel = "linode/latest-64bit"
+	root_password = "%s"
+	swap_size = 256
+	ssh_key = "%s"
+}`, instance, password, pubkey)
+}
+
+func testAccCheckLinodeLinodeConfigRescue(instance string, pubkey string, rescue bool) string {
+	rescueBlock := ""
+	if rescue {
+		rescueBlock = `
+	rescue {
+		devices {
+			sda {
+				disk_label = "root"
+			}
+		}
+	}`
+	}
+	return fmt.Sprintf(`
+resource "linode_linode" "foobar" {
+	name = "%s"
+	type = "g6-nanode-1"
+	region = "us-east"
+	kernel = "linode/latest-64bit"
+
+	disk {
+		label = "root"
+		size = 4096
+		image = "linode/ubuntu18.04"
+		root_password = "terraform-test"
+		ssh_key = ["%s"]
+	}
+%s
+}`, instance, pubkey, rescueBlock)
+}
+
+func testAccCheckLinodeLinodeConfigGeneratedRootPassword(instance string, pubkey string) string {
+	return fmt.Sprintf(`
+resource "linode_linode" "foobar" {
+	name = "%s"
+	type = "g6-nanode-1"
+	image = "linode/ubuntu18.04"
+	region = "us-east"
+	kernel = "linode/latest-64bit"
+	swap_size = 256
+	ssh_key = "%s"
+}`, instance, pubkey)
+}
+
+func testAccCheckLinodeLinodeConfigDisks(instance string, pubkey string) string {
+	return fmt.Sprintf(`
+resource "linode_linode" "foobar" {
+	name = "%s"
+	type = "g6-nanode-1"
+	region = "us-east"
+	kernel = "linode/latest-64bit"
+
+	disk {
+		label = "root"
+		size = 4096
+		image = "linode/ubuntu18.04"
+		root_password = "terraform-test"
+		ssh_key = ["%s"]
+	}
+
+	disk {
+		label = "swap"
+		size = 512
+		filesystem = "swap"
+	}
+
+	disk {
+		label = "data"
+		size = 1024
+	}
+}`, instance, pubkey)
+}
+
+func testAccCheckLinodeLinodeConfigDisksUpdates(instance string, pubkey string) string {
+	return fmt.Sprintf(`
+resource "linode_linode" "foobar" {
+	name = "%s"
+	type = "g6-nanode-1"
+	region = "us-east"
+	kernel = "linode/latest-64bit"
+
+	disk {
+		label = "root"
+		size = 4096
+		image = "linode/ubuntu18.04"
+		root_password = "terraform-test"
+		ssh_key = ["%s"]
+	}
+
+	disk {
+		label = "db"
+		size = 2048
+	}
+}`, instance, pubkey)
+}
+
+func testAccCheckLinodeLinodeConfigImageConfigs(instance string, pubkey string, image string, rootPassword string) string {
+	return fmt.Sprintf(`
+resource "linode_linode" "foobar" {
+	name = "%s"
+	type = "g6-nanode-1"
+	region = "us-east"
+	image = "%s"
+	root_password = "%s"
+	ssh_key = "%s"
+	swap_size = 256
+	rebuild_in_place = true
+
+	config {
+		label = "main"
+		kernel = "linode/grub2"
+	}
+}`, instance, image, rootPassword, pubkey)
+}
+
+func testAccCheckLinodeLinodeConfigConfigs(instance string, pubkey string, bootConfig string) string {
+	return fmt.Sprintf(`
+resource "linode_linode" "foobar" {
+	name = "%s"
+	type = "g6-nanode-1"
+	region = "us-east"
+	boot_config_label = "%s"
+
+	disk {
+		label = "root"
+		size = 4096
+		image = "linode/ubuntu18.04"
+		root_password = "terraform-test"
+		ssh_key = ["%s"]
+	}
+
+	disk {
+		label = "swap"
+		size = 512
+		filesystem = "swap"
+	}
+
+	config {
+		label = "main"
+		kernel = "linode/grub2"
+		devices {
+			sda { disk_label = "root" }
+			sdb { disk_label = "swap" }
+		}
+	}
+
+	config {
+		label = "recovery"
+		run_level = "single"
+		comments = "Boots the root disk in single user mode"
+		helpers {
+			network = false
+		}
+		devices {
+			sda { disk_label = "root" }
+		}
+	}
+}`, instance, bootConfig, pubkey)
+}
+
+func TestMatchLinodeDisks(t *testing.T) {
+	disk := func(id int, label, image, password string) map[string]interface{} {
+		return map[string]interface{}{
+			"id":            id,
+			"label":         label,
+			"filesystem":    "ext4",
+			"image":         image,
+			"root_password": password,
+			"ssh_key":       []interface{}{},
+		}
+	}
+
+	cases := []struct {
+		name     string
+		old      []interface{}
+		new      []interface{}
+		expected []int
+	}{
+		{"same label", []interface{}{disk(1, "boot", "linode/debian9", "a")}, []interface{}{disk(0, "boot", "linode/debian9", "b")}, []int{1}},
+		{"renamed", []interface{}{disk(1, "boot", "linode/debian9", "a")}, []interface{}{disk(0, "root", "linode/debian9", "b")}, []int{1}},
+		{"renamed with another image", []interface{}{disk(1, "boot", "linode/debian9", "a")}, []interface{}{disk(0, "root", "linode/ubuntu18.04", "a")}, []int{0}},
+		{"label taken first", []interface{}{disk(1, "boot", "", ""), disk(2, "data", "", "")}, []interface{}{disk(0, "data", "", ""), disk(0, "logs", "", "")}, []int{2, 1}},
+		{"added", []interface{}{}, []interface{}{disk(0, "boot", "", "")}, []int{0}},
+	}
+
+	for _, c := range cases {
+		matches := matchLinodeDisks(c.old, c.new)
+		for i, match := range matches {
+			id := 0
+			if match != nil {
+				id = match["id"].(int)
+			}
+			if id != c.expected[i] {
+				t.Errorf("%s: expected disk %d to match %d, got %d", c.name, i, c.expected[i], id)
+			}
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package linode
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/chiefy/linodego"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceLinodeVolume() *schema.Resource {
+	return &schema.Resource{
+		Create:        resourceLinodeVolumeCreate,
+		Read:          resourceLinodeVolumeRead,
+		Update:        resourceLinodeVolumeUpdate,
+		Delete:        resourceLinodeVolumeDelete,
+		CustomizeDiff: resourceLinodeVolumeCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"label": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "The label of the Linode Volume.",
+				Required:    true,
+			},
+			"region": &schema.Schema{
+				Type:         schema.TypeString,
+				Description:  "The region where this volume will be deployed.",
+				Required:     true,
+				ForceNew:     true,
+				InputDefault: "us-east",
+			},
+			"size": &schema.Schema{
+				Type:         schema.TypeInt,
+				Description:  "Size of the Volume in GB. Volumes can be grown in place but can not be shrunk.",
+				Optional:     true,
+				Default:      20,
+				ValidateFunc: validateVolumeSize,
+			},
+			"tags": &schema.Schema{
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The tags to apply to the Linode Volume.",
+				Optional:    true,
+			},
+			"linode_id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Description: "The ID of the Linode instance this Volume is attached to, if any.",
+				Computed:    true,
+			},
+			"filesystem_path": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "The full filesystem path for the Volume based on the Volume's label.",
+				Computed:    true,
+			},
+			"status": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "The status of the Volume, indicating the current readiness state.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceLinodeVolumeRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(linodego.Client)
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("Failed to parse Linode Volume ID %s as int because %s", d.Id(), err)
+	}
+
+	volume, err := client.GetVolume(int(id))
+	if err != nil {
+		if lerr, ok := err.(*linodego.Error); ok && lerr.Code == 404 {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Failed to find the specified Linode Volume because %s", err)
+	}
+
+	d.Set("label", volume.Label)
+	d.Set("region", volume.Region)
+	d.Set("size", volume.Size)
+	d.Set("tags", volume.Tags)
+	d.Set("linode_id", volume.LinodeID)
+	d.Set("filesystem_path", volume.FilesystemPath)
+	d.Set("status", volume.Status)
+
+	return nil
+}
+
+func resourceLinodeVolumeCreate(d *schema.ResourceData, meta interface{}) error {
+	client, ok := meta.(linodego.Client)
+	if !ok {
+		return fmt.Errorf("Invalid Client when creating Linode Volume")
+	}
+
+	createOpts := linodego.VolumeCreateOptions{
+		Label:  d.Get("label").(string),
+		Region: d.Get("region").(string),
+		Size:   d.Get("size").(int),
+		Tags:   expandStringList(d.Get("tags").([]interface{})),
+	}
+
+	volume, err := client.CreateVolume(&createOpts)
+	if err != nil {
+		return fmt.Errorf("Failed to create a Linode Volume in region %s because %s", d.Get("region"), err)
+	}
+	d.SetId(fmt.Sprintf("%d", volume.ID))
+
+	_, err = client.WaitForEventFinished(volume.ID, linodego.EntityVolume, linodego.ActionVolumeCreate, *volume.Created, WaitTimeout)
+	if err != nil {
+		return fmt.Errorf("Failed waiting for Linode Volume %d to finish creating because %s", volume.ID, err)
+	}
+
+	return resourceLinodeVolumeRead(d, meta)
+}
+
+func resourceLinodeVolumeUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(linodego.Client)
+	d.Partial(true)
+
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("Failed to parse Linode Volume ID %s as int because %s", d.Id(), err)
+	}
+
+	volume, err := client.GetVolume(int(id))
+	if err != nil {
+		return fmt.Errorf("Failed to fetch data about the current Linode Volume because %s", err)
+	}
+
+	if d.HasChange("label") || d.HasChange("tags") {
+		updateOpts := linodego.VolumeUpdateOptions{
+			Label: d.Get("label").(string),
+			Tags:  expandStringList(d.Get("tags").([]interface{})),
+		}
+		if volume, err = client.UpdateVolume(volume.ID, &updateOpts); err != nil {
+			return fmt.Errorf("Failed to update Linode Volume %d because %s", id, err)
+		}
+		d.SetPartial("label")
+		d.SetPartial("tags")
+	}
+
+	if d.HasChange("size") {
+		size := d.Get("size").(int)
+		if _, err := client.ResizeVolume(volume.ID, size); err != nil {
+			return fmt.Errorf("Failed to resize Linode Volume %d to %d GB because %s", volume.ID, size, err)
+		}
+
+		_, err = client.WaitForEventFinished(volume.ID, linodego.EntityVolume, linodego.ActionVolumeResize, *volume.Updated, WaitTimeout)
+		if err != nil {
+			return fmt.Errorf("Failed waiting for Linode Volume %d to finish resizing because %s", volume.ID, err)
+		}
+		d.SetPartial("size")
+	}
+
+	d.Partial(false)
+
+	return resourceLinodeVolumeRead(d, meta)
+}
+
+func resourceLinodeVolumeDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(linodego.Client)
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("Failed to parse Linode Volume ID %s as int", d.Id())
+	}
+
+	volume, err := client.GetVolume(int(id))
+	if err != nil {
+		return fmt.Errorf("Failed to fetch data about the current Linode Volume because %s", err)
+	}
+
+	if err = client.DeleteVolume(volume.ID); err != nil {
+		return fmt.Errorf("Failed to delete Linode Volume %d because %s", id, err)
+	}
+
+	_, err = client.WaitForEventFinished(volume.ID, linodego.EntityVolume, linodego.ActionVolumeDelete, *volume.Updated, WaitTimeout)
+	if err != nil {
+		return fmt.Errorf("Failed waiting for Linode Volume %d to finish deleting because %s", volume.ID, err)
+	}
+
+	return nil
+}
+
+// resourceLinodeVolumeCustomizeDiff rejects plans that would shrink a Volume, which the API does not support
+func resourceLinodeVolumeCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.HasChange("size") {
+		return nil
+	}
+
+	o, n := d.GetChange("size")
+	if n.(int) < o.(int) {
+		return fmt.Errorf("Linode Volume %s can not be shrunk from %d GB to %d GB", d.Id(), o.(int), n.(int))
+	}
+	return nil
+}
+
+// validateVolumeSize checks that a Volume size is within the range the API accepts
+func validateVolumeSize(v interface{}, k string) (ws []string, es []error) {
+	size := v.(int)
+	if size < 10 || size > 10240 {
+		es = append(es, fmt.Errorf("%q must be between 10 and 10240 GB, got %d", k, size))
+	}
+	return
+}
+
+// expandStringList converts a list of interfaces from the schema into a list of strings
+func expandStringList(list []interface{}) []string {
+	result := make([]string, 0, len(list))
+	for _, v := range list {
+		if val, ok := v.(string); ok {
+			result = append(result, val)
+		}
+	}
+	return result
+}
@@ -0,0 +1,231 @@
+package linode
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/chiefy/linodego"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceLinodeVolumeAttachment() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceLinodeVolumeAttachmentCreate,
+		Read:   resourceLinodeVolumeAttachmentRead,
+		Update: resourceLinodeVolumeAttachmentUpdate,
+		Delete: resourceLinodeVolumeAttachmentDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"volume_id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Description: "The ID of the Linode Volume to attach.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"linode_id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Description: "The ID of the Linode instance to attach the Volume to.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"config_id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Description: "The ID of the Linode Config to attach the Volume to. Defaults to the first config of the Linode instance.",
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"device": &schema.Schema{
+				Type:         schema.TypeString,
+				Description:  "The device slot (sdc-sdh) the Volume is assigned to in the Linode Config.",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateVolumeAttachmentDevice,
+			},
+		},
+	}
+}
+
+func resourceLinodeVolumeAttachmentRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(linodego.Client)
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("Failed to parse Linode Volume ID %s as int because %s", d.Id(), err)
+	}
+
+	volume, err := client.GetVolume(int(id))
+	if err != nil {
+		if lerr, ok := err.(*linodego.Error); ok && lerr.Code == 404 {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Failed to find the specified Linode Volume because %s", err)
+	}
+
+	// The Volume was detached or moved outside of Terraform
+	if volume.LinodeID == 0 {
+		d.SetId("")
+		return nil
+	}
+
+	configs, err := client.ListInstanceConfigs(volume.LinodeID, nil)
+	if err != nil {
+		return fmt.Errorf("Failed to get the configs for Linode instance %d because %s", volume.LinodeID, err)
+	}
+
+	configID, device := d.Get("config_id").(int), ""
+	for _, config := range configs {
+		slot := findConfigVolumeSlot(config.Devices, volume.ID)
+		if slot == "" {
+			continue
+		}
+		if device == "" || config.ID == configID {
+			configID, device = config.ID, slot
+		}
+	}
+
+	d.Set("volume_id", volume.ID)
+	d.Set("linode_id", volume.LinodeID)
+	d.Set("config_id", configID)
+	d.Set("device", device)
+
+	return nil
+}
+
+func resourceLinodeVolumeAttachmentCreate(d *schema.ResourceData, meta interface{}) error {
+	client, ok := meta.(linodego.Client)
+	if !ok {
+		return fmt.Errorf("Invalid Client when attaching Linode Volume")
+	}
+
+	volumeID, linodeID := d.Get("volume_id").(int), d.Get("linode_id").(int)
+
+	volume, err := client.GetVolume(volumeID)
+	if err != nil {
+		return fmt.Errorf("Failed to fetch data about Linode Volume %d because %s", volumeID, err)
+	}
+
+	configID := d.Get("config_id").(int)
+	if configID == 0 {
+		configs, err := client.ListInstanceConfigs(linodeID, nil)
+		if err != nil {
+			return fmt.Errorf("Failed to get the configs for Linode instance %d because %s", linodeID, err)
+		}
+		if len(configs) == 0 {
+			return fmt.Errorf("Linode instance %d has no configs to attach Volume %d to", linodeID, volumeID)
+		}
+		configID = configs[0].ID
+	}
+
+	attachOpts := linodego.VolumeAttachOptions{
+		LinodeID: linodeID,
+		ConfigID: configID,
+	}
+	if _, err := client.AttachVolume(volumeID, &attachOpts); err != nil {
+		return fmt.Errorf("Failed to attach Linode Volume %d to Linode instance %d because %s", volumeID, linodeID, err)
+	}
+	d.SetId(fmt.Sprintf("%d", volumeID))
+	d.Set("config_id", configID)
+
+	_, err = client.WaitForEventFinished(volumeID, linodego.EntityVolume, linodego.ActionVolumeAttach, *volume.Updated, WaitTimeout)
+	if err != nil {
+		return fmt.Errorf("Failed waiting for Linode Volume %d to finish attaching because %s", volumeID, err)
+	}
+
+	if device, ok := d.GetOk("device"); ok {
+		if err := moveVolumeAttachmentDevice(&client, linodeID, configID, volumeID, device.(string)); err != nil {
+			return err
+		}
+	}
+
+	return resourceLinodeVolumeAttachmentRead(d, meta)
+}
+
+func resourceLinodeVolumeAttachmentUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(linodego.Client)
+
+	if d.HasChange("device") {
+		volumeID, linodeID, configID := d.Get("volume_id").(int), d.Get("linode_id").(int), d.Get("config_id").(int)
+		if err := moveVolumeAttachmentDevice(&client, linodeID, configID, volumeID, d.Get("device").(string)); err != nil {
+			return err
+		}
+	}
+
+	return resourceLinodeVolumeAttachmentRead(d, meta)
+}
+
+func resourceLinodeVolumeAttachmentDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(linodego.Client)
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("Failed to parse Linode Volume ID %s as int", d.Id())
+	}
+
+	volume, err := client.GetVolume(int(id))
+	if err != nil {
+		return fmt.Errorf("Failed to fetch data about Linode Volume %d because %s", id, err)
+	}
+	// The Volume may have been detached, or attached to another Linode instance, since it was last read
+	if volume.LinodeID == 0 || volume.LinodeID != d.Get("linode_id").(int) {
+		return nil
+	}
+
+	minStart := time.Now()
+	if _, err := client.DetachVolume(volume.ID); err != nil {
+		return fmt.Errorf("Failed to detach Linode Volume %d because %s", volume.ID, err)
+	}
+
+	_, err = client.WaitForEventFinished(volume.ID, linodego.EntityVolume, linodego.ActionVolumeDetach, minStart, WaitTimeout)
+	if err != nil {
+		return fmt.Errorf("Failed waiting for Linode Volume %d to finish detaching because %s", volume.ID, err)
+	}
+
+	return nil
+}
+
+// moveVolumeAttachmentDevice assigns the Volume to the given device slot of the Linode Config,
+// rebooting the instance into that config so the new device map takes effect.
+func moveVolumeAttachmentDevice(client *linodego.Client, linodeID int, configID int, volumeID int, device string) error {
+	config, err := client.GetInstanceConfig(linodeID, configID)
+	if err != nil {
+		return fmt.Errorf("Failed to get config %d for Linode instance %d because %s", configID, linodeID, err)
+	}
+	if config.Devices == nil {
+		config.Devices = &linodego.InstanceConfigDeviceMap{}
+	}
+
+	current := findConfigVolumeSlot(config.Devices, volumeID)
+	if current == device {
+		return nil
+	}
+
+	if occupant := getConfigDevice(config.Devices, device); occupant != nil && (occupant.DiskID != 0 || occupant.VolumeID != 0) {
+		return fmt.Errorf("Device %s of config %d for Linode instance %d is already in use", device, configID, linodeID)
+	}
+
+	if current != "" {
+		setConfigDevice(config.Devices, current, nil)
+	}
+	if err := setConfigDevice(config.Devices, device, &linodego.InstanceConfigDevice{VolumeID: volumeID}); err != nil {
+		return err
+	}
+
+	if config, err = client.UpdateInstanceConfig(linodeID, configID, config.GetUpdateOptions()); err != nil {
+		return fmt.Errorf("Failed to update config %d for Linode instance %d because %s", configID, linodeID, err)
+	}
+
+	return rebootInstanceIfRunning(client, linodeID, config)
+}
+
+// validateVolumeAttachmentDevice checks that a device slot is one a Volume may be assigned to
+func validateVolumeAttachmentDevice(v interface{}, k string) (ws []string, es []error) {
+	switch v.(string) {
+	case "sdc", "sdd", "sde", "sdf", "sdg", "sdh":
+	default:
+		es = append(es, fmt.Errorf("%q must be one of sdc, sdd, sde, sdf, sdg or sdh, got %s", k, v))
+	}
+	return
+}
@@ -0,0 +1,132 @@
+package linode
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/chiefy/linodego"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccLinodeVolumeAttachmentBasic(t *testing.T) {
+	t.Parallel()
+
+	resName := "linode_volume_attachment.foobar"
+	var name = fmt.Sprintf("tf_test_%s", acctest.RandString(10))
+	publicKeyMaterial, _, err := acctest.RandSSHKeyPair("linode@ssh-acceptance-test")
+	if err != nil {
+		t.Fatalf("Cannot generate test SSH key pair: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLinodeVolumeAttachmentDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckLinodeVolumeAttachmentConfigBasic(name, publicKeyMaterial, "sdc"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeVolumeAttachmentExists,
+					resource.TestCheckResourceAttrPair(resName, "volume_id", "linode_volume.foobar", "id"),
+					resource.TestCheckResourceAttrPair(resName, "linode_id", "linode_linode.foobar", "id"),
+					resource.TestCheckResourceAttrSet(resName, "config_id"),
+					resource.TestCheckResourceAttr(resName, "device", "sdc"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccCheckLinodeVolumeAttachmentConfigBasic(name, publicKeyMaterial, "sdd"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeVolumeAttachmentExists,
+					resource.TestCheckResourceAttr(resName, "device", "sdd"),
+				),
+			},
+
+			resource.TestStep{
+				ResourceName:      resName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckLinodeVolumeAttachmentExists(s *terraform.State) error {
+	client := testAccProvider.Meta().(linodego.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "linode_volume_attachment" {
+			continue
+		}
+
+		id, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("Failed parsing %v to int", rs.Primary.ID)
+		}
+
+		volume, err := client.GetVolume(id)
+		if err != nil {
+			return fmt.Errorf("Error retrieving state of Volume %d: %s", id, err)
+		}
+		if strconv.Itoa(volume.LinodeID) != rs.Primary.Attributes["linode_id"] {
+			return fmt.Errorf("Volume %d is attached to Linode %d, expected %s", id, volume.LinodeID, rs.Primary.Attributes["linode_id"])
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckLinodeVolumeAttachmentDestroy(s *terraform.State) error {
+	client, ok := testAccProvider.Meta().(linodego.Client)
+	if !ok {
+		return fmt.Errorf("Failed to get Linode client")
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "linode_volume_attachment" {
+			continue
+		}
+
+		id, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("Failed parsing %v to int", rs.Primary.ID)
+		}
+
+		volume, err := client.GetVolume(id)
+		if err == nil && volume.LinodeID != 0 {
+			return fmt.Errorf("Linode Volume with id %d is still attached to Linode %d", id, volume.LinodeID)
+		}
+
+		if apiErr, ok := err.(*linodego.Error); ok && apiErr.Code != 404 {
+			return fmt.Errorf("Failed to request Linode Volume with id %d", id)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckLinodeVolumeAttachmentConfigBasic(name string, pubkey string, device string) string {
+	return fmt.Sprintf(`
+resource "linode_linode" "foobar" {
+	name = "%s"
+	type = "g6-nanode-1"
+	image = "linode/ubuntu18.04"
+	region = "us-east"
+	kernel = "linode/latest-64bit"
+	root_password = "terraform-test"
+	swap_size = 256
+	ssh_key = "%s"
+}
+
+resource "linode_volume" "foobar" {
+	label = "%s"
+	region = "us-east"
+}
+
+resource "linode_volume_attachment" "foobar" {
+	volume_id = "${linode_volume.foobar.id}"
+	linode_id = "${linode_linode.foobar.id}"
+	device = "%s"
+}`, name, pubkey, name, device)
+}
@@ -0,0 +1,184 @@
+package linode
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/chiefy/linodego"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccLinodeVolumeBasic(t *testing.T) {
+	t.Parallel()
+
+	resName := "linode_volume.foobar"
+	var volumeName = fmt.Sprintf("tf_test_%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLinodeVolumeDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckLinodeVolumeConfigBasic(volumeName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeVolumeExists,
+					resource.TestCheckResourceAttr(resName, "label", volumeName),
+					resource.TestCheckResourceAttr(resName, "region", "us-east"),
+					resource.TestCheckResourceAttr(resName, "size", "20"),
+					resource.TestCheckResourceAttr(resName, "status", "active"),
+					resource.TestCheckResourceAttrSet(resName, "filesystem_path"),
+				),
+			},
+
+			resource.TestStep{
+				ResourceName:      resName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccLinodeVolumeUpdate(t *testing.T) {
+	t.Parallel()
+
+	resName := "linode_volume.foobar"
+	var volumeName = fmt.Sprintf("tf_test_%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLinodeVolumeDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckLinodeVolumeConfigBasic(volumeName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeVolumeExists,
+					resource.TestCheckResourceAttr(resName, "label", volumeName),
+				),
+			},
+			resource.TestStep{
+				Config: testAccCheckLinodeVolumeConfigUpdates(volumeName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeVolumeExists,
+					resource.TestCheckResourceAttr(resName, "label", fmt.Sprintf("%s_r", volumeName)),
+					resource.TestCheckResourceAttr(resName, "tags.#", "1"),
+					resource.TestCheckResourceAttr(resName, "tags.0", "tf_test"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccLinodeVolumeResize(t *testing.T) {
+	t.Parallel()
+
+	resName := "linode_volume.foobar"
+	var volumeName = fmt.Sprintf("tf_test_%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLinodeVolumeDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckLinodeVolumeConfigBasic(volumeName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeVolumeExists,
+					resource.TestCheckResourceAttr(resName, "size", "20"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccCheckLinodeVolumeConfigResized(volumeName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLinodeVolumeExists,
+					resource.TestCheckResourceAttr(resName, "size", "30"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckLinodeVolumeExists(s *terraform.State) error {
+	client := testAccProvider.Meta().(linodego.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "linode_volume" {
+			continue
+		}
+
+		id, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("Failed parsing %v to int", rs.Primary.ID)
+		}
+
+		_, err = client.GetVolume(id)
+		if err != nil {
+			return fmt.Errorf("Error retrieving state of Volume %s: %s", rs.Primary.Attributes["label"], err)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckLinodeVolumeDestroy(s *terraform.State) error {
+	client, ok := testAccProvider.Meta().(linodego.Client)
+	if !ok {
+		return fmt.Errorf("Failed to get Linode client")
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "linode_volume" {
+			continue
+		}
+
+		id, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("Failed parsing %v to int", rs.Primary.ID)
+		}
+		if id == 0 {
+			return fmt.Errorf("Would have considered %v as %d", rs.Primary.ID, id)
+		}
+
+		_, err = client.GetVolume(id)
+
+		if err == nil {
+			return fmt.Errorf("Linode Volume with id %d still exists", id)
+		}
+
+		if apiErr, ok := err.(*linodego.Error); ok && apiErr.Code != 404 {
+			return fmt.Errorf("Failed to request Linode Volume with id %d", id)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckLinodeVolumeConfigBasic(volume string) string {
+	return fmt.Sprintf(`
+resource "linode_volume" "foobar" {
+	label = "%s"
+	region = "us-east"
+}`, volume)
+}
+
+func testAccCheckLinodeVolumeConfigUpdates(volume string) string {
+	return fmt.Sprintf(`
+resource "linode_volume" "foobar" {
+	label = "%s_r"
+	region = "us-east"
+	tags = ["tf_test"]
+}`, volume)
+}
+
+func testAccCheckLinodeVolumeConfigResized(volume string) string {
+	return fmt.Sprintf(`
+resource "linode_volume" "foobar" {
+	label = "%s"
+	region = "us-east"
+	size = 30
+}`, volume)
+}